@@ -0,0 +1,59 @@
+// Package appctx carries request-scoped values, such as who is calling and the
+// request id, from the gRPC layer down to storage.
+package appctx
+
+import "context"
+
+type key int
+
+const (
+	actorKey key = iota
+	requestIDKey
+	userIDKey
+	roleKey
+)
+
+// WithActor returns a copy of ctx carrying the id of the user making the request
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// Actor returns the id of the user making the request, or "" for background jobs
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// WithRequestID returns a copy of ctx carrying the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the id of the current request, or "" outside of a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithUserID returns a copy of ctx whose storage access is limited to the given user's data
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID returns the user whose data the request may access, or "" when it isn't
+// limited to one user, as for background jobs and admin tools
+func UserID(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKey).(string)
+	return userID
+}
+
+// WithRole returns a copy of ctx carrying the role of the user making the request
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// Role returns the role of the user making the request, or "" for background jobs
+func Role(ctx context.Context) string {
+	role, _ := ctx.Value(roleKey).(string)
+	return role
+}
@@ -0,0 +1,73 @@
+// Package apperr defines the errors storage and services return when the caller,
+// not the server, is at fault. The gRPC layer maps each kind to a status code.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind says what went wrong from the caller's point of view
+type Kind int
+
+const (
+	// KindNotFound means the referenced item doesn't exist or is in the trash
+	KindNotFound Kind = iota + 1
+	// KindInvalidArgument means a request field is malformed or out of range
+	KindInvalidArgument
+	// KindAlreadyExists means the item being created is already there
+	KindAlreadyExists
+	// KindFailedPrecondition means the request is valid but the current state doesn't allow it
+	KindFailedPrecondition
+)
+
+// Error is a typed error. Subject names what the error is about: the entity for
+// NotFound and AlreadyExists, the field for InvalidArgument and the rule for
+// FailedPrecondition. ID is the ID of the item when there is one.
+type Error struct {
+	Kind    Kind
+	Subject string
+	ID      string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NotFound reports that the entity with the given ID doesn't exist
+func NotFound(entity, id string) error {
+	message := fmt.Sprintf("%s not found", entity)
+	if id != "" {
+		message = fmt.Sprintf("%s %s not found", entity, id)
+	}
+	return &Error{Kind: KindNotFound, Subject: entity, ID: id, Message: message}
+}
+
+// InvalidArgument reports a bad value in the given request field
+func InvalidArgument(field, format string, args ...interface{}) error {
+	return &Error{Kind: KindInvalidArgument, Subject: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// AlreadyExists reports that the entity with the given ID is already there
+func AlreadyExists(entity, id string) error {
+	return &Error{Kind: KindAlreadyExists, Subject: entity, ID: id, Message: fmt.Sprintf("%s %s already exists", entity, id)}
+}
+
+// FailedPrecondition reports that a rule, such as "account is open", blocks the request
+func FailedPrecondition(rule, format string, args ...interface{}) error {
+	return &Error{Kind: KindFailedPrecondition, Subject: rule, Message: fmt.Sprintf(format, args...)}
+}
+
+// As returns the typed error in err's chain, if there is one
+func As(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// Is reports whether err is a typed error of the given kind
+func Is(err error, kind Kind) bool {
+	e, ok := As(err)
+	return ok && e.Kind == kind
+}
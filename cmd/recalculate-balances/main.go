@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"budget-service/appctx"
+	"budget-service/config"
+	pb "budget-service/genproto"
+	"budget-service/service"
+	"budget-service/storage/audit"
+	postgres "budget-service/storage/mongo"
+)
+
+// Rebuilds account balances from the transaction ledger.
+// Without -fix it only reports the accounts whose stored balance is off.
+func main() {
+	userId := flag.String("user", "", "only check accounts of this user")
+	accountId := flag.String("account", "", "only check this account")
+	fix := flag.Bool("fix", false, "write the recomputed balances")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Error in configuration: ", err.Error())
+	}
+	db, err := postgres.NewMongoConnection(cfg.MongoURI, cfg.MongoDatabase)
+	if err != nil {
+		log.Fatal("Error while connection on db: ", err.Error())
+	}
+	defer db.Close(context.Background())
+
+	// Fixed balances show up in the audit log under this tool's name
+	ctx := appctx.WithActor(context.Background(), "recalculate-balances")
+	stg := audit.New(db)
+	accounts := service.NewAccountService(stg, service.NewUserSettingsService(stg, cfg.BaseCurrency))
+	resp, err := accounts.RecalculateBalances(ctx, &pb.RecalculateBalancesRequest{
+		UserId:    *userId,
+		AccountId: *accountId,
+		Fix:       *fix,
+	})
+	if err != nil {
+		log.Fatalf("Failed to recalculate balances: %v", err)
+	}
+
+	for _, d := range resp.Discrepancies {
+		log.Printf("account %s (user %s): stored %.2f, computed %.2f, difference %.2f",
+			d.AccountId, d.UserId, d.StoredBalance, d.ComputedBalance, d.Difference)
+	}
+	log.Printf("checked %d accounts, %d discrepancies, %d fixed", resp.Checked, len(resp.Discrepancies), resp.Fixed)
+}
@@ -1,58 +1,260 @@
 package config
 
 import (
+  "errors"
   "fmt"
+  "net"
+  "net/url"
   "os"
+  "regexp"
+  "strconv"
   "strings"
+  "time"
 
   "github.com/joho/godotenv"
   "github.com/spf13/cast"
+  "gopkg.in/yaml.v3"
 )
 
 type Config struct {
+  GRPCPort string
   HTTPPort string
 
-  DB_HOST     string
-  DB_PORT     int
-  DB_USER     string
-  DB_PASSWORD string
-  DB_NAME string
+  MongoURI      string
+  MongoDatabase string
 
   KafkaBrokers []string
+  KafkaTopic   string
+  KafkaGroupID string
 
   DefaultOffset string
   DefaultLimit  string
 
-  TokenKey string
+  TokenKey         string
+  JWTPublicKeyFile string
+  PolicyFile       string
+
+  BaseCurrency      string
+  ExchangeRatesFile string
+
+  TrashRetentionDays int
+
+  ShutdownTimeout time.Duration
+
+  GRPCReflection      bool
+  HealthCheckInterval time.Duration
+  MaxConsumerLag      int64
 }
 
-func Load() Config {
-  if err := godotenv.Load(); err != nil {
+// Load reads the configuration from the environment, then the .env file, then the
+// YAML file named by CONFIG_FILE, each filling in only what the ones before left
+// unset, and falls back to the defaults for the rest. An empty value counts as
+// unset. The result is validated.
+func Load() (Config, error) {
+  env, err := godotenv.Read()
+  if err != nil {
     fmt.Println("No .env file found")
   }
+  for key, value := range env {
+    if os.Getenv(key) == "" {
+      os.Setenv(key, value)
+    }
+  }
+  if path := os.Getenv("CONFIG_FILE"); path != "" {
+    if err := loadFile(path); err != nil {
+      return Config{}, fmt.Errorf("CONFIG_FILE: %v", err)
+    }
+  }
 
   config := Config{}
+  var errs []error
 
+  config.GRPCPort = cast.ToString(GetOrReturnDefaultValue("GRPC_PORT", ":8088"))
   config.HTTPPort = cast.ToString(GetOrReturnDefaultValue("HTTP_PORT", ":8070"))
 
-  config.DB_HOST = cast.ToString(GetOrReturnDefaultValue("DB_HOST", "localhost"))
-  config.DB_PORT = cast.ToInt(GetOrReturnDefaultValue("DB_PORT", 5432))
-  config.DB_USER = cast.ToString(GetOrReturnDefaultValue("DB_USER", "postgres"))
-  config.DB_PASSWORD = cast.ToString(GetOrReturnDefaultValue("DB_PASSWORD", "root"))
-  config.DB_NAME = cast.ToString(GetOrReturnDefaultValue("DB_NAME", "olympy"))
+  config.MongoURI = cast.ToString(GetOrReturnDefaultValue("MONGO_URI", "mongodb://localhost:27017"))
+  config.MongoDatabase = cast.ToString(GetOrReturnDefaultValue("MONGO_DATABASE", "budget"))
 
   config.KafkaBrokers = parseKafkaBrokers(GetOrReturnDefaultValue("KAFKA_BROKERS", "localhost:9092"))
+  config.KafkaTopic = cast.ToString(GetOrReturnDefaultValue("KAFKA_TOPIC", "create"))
+  config.KafkaGroupID = cast.ToString(GetOrReturnDefaultValue("KAFKA_GROUP_ID", "root"))
 
   config.DefaultOffset = cast.ToString(GetOrReturnDefaultValue("DEFAULT_OFFSET", "0"))
   config.DefaultLimit = cast.ToString(GetOrReturnDefaultValue("DEFAULT_LIMIT", "10"))
-  config.TokenKey = cast.ToString(GetOrReturnDefaultValue("TokenKey", "my_secret_key"))
-  return config
+  config.TokenKey = cast.ToString(GetOrReturnDefaultValue("TOKEN_KEY", ""))
+  config.JWTPublicKeyFile = cast.ToString(GetOrReturnDefaultValue("JWT_PUBLIC_KEY_FILE", ""))
+  config.PolicyFile = cast.ToString(GetOrReturnDefaultValue("POLICY_FILE", "policy.yaml"))
+
+  config.BaseCurrency = cast.ToString(GetOrReturnDefaultValue("BASE_CURRENCY", "UZS"))
+  config.ExchangeRatesFile = cast.ToString(GetOrReturnDefaultValue("EXCHANGE_RATES_FILE", ""))
+
+  days, err := cast.ToIntE(GetOrReturnDefaultValue("TRASH_RETENTION_DAYS", 30))
+  if err != nil {
+    errs = append(errs, fmt.Errorf("TRASH_RETENTION_DAYS: %q is not a whole number", os.Getenv("TRASH_RETENTION_DAYS")))
+    // Already reported, so Validate doesn't complain about it again
+    days = 30
+  }
+  config.TrashRetentionDays = days
+
+  timeout, err := time.ParseDuration(cast.ToString(GetOrReturnDefaultValue("SHUTDOWN_TIMEOUT", "30s")))
+  if err != nil {
+    errs = append(errs, fmt.Errorf("SHUTDOWN_TIMEOUT: %q is not a duration like 30s", os.Getenv("SHUTDOWN_TIMEOUT")))
+    timeout = 30 * time.Second
+  }
+  config.ShutdownTimeout = timeout
+
+  reflection, err := cast.ToBoolE(GetOrReturnDefaultValue("GRPC_REFLECTION", false))
+  if err != nil {
+    errs = append(errs, fmt.Errorf("GRPC_REFLECTION: %q is not true or false", os.Getenv("GRPC_REFLECTION")))
+  }
+  config.GRPCReflection = reflection
+
+  interval, err := time.ParseDuration(cast.ToString(GetOrReturnDefaultValue("HEALTH_CHECK_INTERVAL", "10s")))
+  if err != nil {
+    errs = append(errs, fmt.Errorf("HEALTH_CHECK_INTERVAL: %q is not a duration like 10s", os.Getenv("HEALTH_CHECK_INTERVAL")))
+    interval = 10 * time.Second
+  }
+  config.HealthCheckInterval = interval
+
+  lag, err := cast.ToInt64E(GetOrReturnDefaultValue("MAX_CONSUMER_LAG", 1000))
+  if err != nil {
+    errs = append(errs, fmt.Errorf("MAX_CONSUMER_LAG: %q is not a whole number", os.Getenv("MAX_CONSUMER_LAG")))
+  }
+  config.MaxConsumerLag = lag
+
+  if err := errors.Join(append(errs, config.Validate())...); err != nil {
+    return Config{}, err
+  }
+  return config, nil
 }
 
-func GetOrReturnDefaultValue(key string, defaultValue interface{}) interface{} {
-  val, exists := os.LookupEnv(key)
+// loadFile sets the variables of a YAML file of NAME: value pairs that aren't set
+// already. A list value becomes a comma-separated one, so KAFKA_BROKERS can be either.
+func loadFile(path string) error {
+  data, err := os.ReadFile(path)
+  if err != nil {
+    return err
+  }
+  var values map[string]interface{}
+  if err := yaml.Unmarshal(data, &values); err != nil {
+    return fmt.Errorf("parse %s: %v", path, err)
+  }
+
+  for key, value := range values {
+    if os.Getenv(key) != "" {
+      continue
+    }
+    var s string
+    switch v := value.(type) {
+    case []interface{}:
+      items, err := cast.ToStringSliceE(v)
+      if err != nil {
+        return fmt.Errorf("%s in %s: %v", key, path, err)
+      }
+      s = strings.Join(items, ",")
+    case map[string]interface{}:
+      return fmt.Errorf("%s in %s: expected a value or a list", key, path)
+    default:
+      s = cast.ToString(v)
+    }
+    if err := os.Setenv(key, s); err != nil {
+      return err
+    }
+  }
+  return nil
+}
 
-  if exists {
+// defaultTokenKey was once the built-in TOKEN_KEY; anyone can sign tokens with it
+const defaultTokenKey = "my_secret_key"
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate reports every setting the server can't start with, each prefixed with its variable
+func (c Config) Validate() error {
+  var errs []error
+  fail := func(key, format string, args ...interface{}) {
+    errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+  }
+
+  if err := checkAddress(c.GRPCPort); err != nil {
+    fail("GRPC_PORT", "%q is not a listen address like :8088: %v", c.GRPCPort, err)
+  }
+  if err := checkAddress(c.HTTPPort); err != nil {
+    fail("HTTP_PORT", "%q is not a listen address like :8070: %v", c.HTTPPort, err)
+  }
+  if c.GRPCPort == c.HTTPPort {
+    fail("HTTP_PORT", "must differ from GRPC_PORT %q", c.GRPCPort)
+  }
+
+  if u, err := url.Parse(c.MongoURI); err != nil {
+    fail("MONGO_URI", "%v", err)
+  } else if u.Scheme != "mongodb" && u.Scheme != "mongodb+srv" {
+    fail("MONGO_URI", "%q must start with mongodb:// or mongodb+srv://", c.MongoURI)
+  }
+  if c.MongoDatabase == "" || strings.ContainsAny(c.MongoDatabase, `/\. "$`) {
+    fail("MONGO_DATABASE", "%q is not a valid database name", c.MongoDatabase)
+  }
+
+  if len(c.KafkaBrokers) == 0 {
+    fail("KAFKA_BROKERS", "at least one broker is required")
+  }
+  for _, broker := range c.KafkaBrokers {
+    if host, _, err := net.SplitHostPort(broker); err != nil || host == "" {
+      fail("KAFKA_BROKERS", "%q is not a host:port address", broker)
+    }
+  }
+  if c.KafkaTopic == "" {
+    fail("KAFKA_TOPIC", "is required")
+  }
+  if c.KafkaGroupID == "" {
+    fail("KAFKA_GROUP_ID", "is required")
+  }
+
+  // Without a public key tokens are checked with the shared key, so it must be a real secret
+  if c.JWTPublicKeyFile == "" {
+    if c.TokenKey == "" {
+      fail("TOKEN_KEY", "is required unless JWT_PUBLIC_KEY_FILE is set")
+    } else if c.TokenKey == defaultTokenKey {
+      fail("TOKEN_KEY", "must not be the well-known example key")
+    }
+  }
+  if c.PolicyFile == "" {
+    fail("POLICY_FILE", "is required")
+  }
+  if !currencyCode.MatchString(c.BaseCurrency) {
+    fail("BASE_CURRENCY", "%q is not a three-letter currency code", c.BaseCurrency)
+  }
+  if c.TrashRetentionDays <= 0 {
+    fail("TRASH_RETENTION_DAYS", "must be positive, got %d", c.TrashRetentionDays)
+  }
+  if c.ShutdownTimeout <= 0 {
+    fail("SHUTDOWN_TIMEOUT", "must be positive, got %v", c.ShutdownTimeout)
+  }
+  if c.HealthCheckInterval <= 0 {
+    fail("HEALTH_CHECK_INTERVAL", "must be positive, got %v", c.HealthCheckInterval)
+  }
+  if c.MaxConsumerLag < 0 {
+    fail("MAX_CONSUMER_LAG", "must not be negative, got %d", c.MaxConsumerLag)
+  }
+  return errors.Join(errs...)
+}
+
+// checkAddress accepts [host]:port with a port from 1 to 65535
+func checkAddress(addr string) error {
+  _, port, err := net.SplitHostPort(addr)
+  if err != nil {
+    return err
+  }
+  n, err := strconv.Atoi(port)
+  if err != nil || n < 1 || n > 65535 {
+    return fmt.Errorf("port %q out of range", port)
+  }
+  return nil
+}
+
+// GetOrReturnDefaultValue returns the variable's value, or defaultValue when it is unset or empty
+func GetOrReturnDefaultValue(key string, defaultValue interface{}) interface{} {
+  if val := os.Getenv(key); val != "" {
     return val
   }
 
@@ -62,7 +264,13 @@ func GetOrReturnDefaultValue(key string, defaultValue interface{}) interface{} {
 func parseKafkaBrokers(brokers interface{}) []string {
   switch v := brokers.(type) {
   case string:
-    return strings.Split(v, ",")
+    var list []string
+    for _, broker := range strings.Split(v, ",") {
+      if broker = strings.TrimSpace(broker); broker != "" {
+        list = append(list, broker)
+      }
+    }
+    return list
   case []string:
     return v
   default:
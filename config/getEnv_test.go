@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// unsetenv clears the variables for the test and puts them back afterwards, since
+// Load sets the ones it reads from .env and the config file
+func unsetenv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		old, had := os.LookupEnv(key)
+		os.Unsetenv(key)
+		t.Cleanup(func() {
+			if had {
+				os.Setenv(key, old)
+			} else {
+				os.Unsetenv(key)
+			}
+		})
+	}
+}
+
+// chdir runs the test in dir, where Load looks for .env
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	unsetenv(t, "CONFIG_FILE", "TOKEN_KEY", "JWT_PUBLIC_KEY_FILE", "GRPC_PORT", "HTTP_PORT",
+		"MONGO_URI", "MONGO_DATABASE", "KAFKA_BROKERS", "KAFKA_TOPIC", "KAFKA_GROUP_ID", "SHUTDOWN_TIMEOUT")
+
+	dir := t.TempDir()
+	chdir(t, dir)
+	file := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(file, []byte(`
+GRPC_PORT: ":9002"
+HTTP_PORT: ":9003"
+MONGO_DATABASE: from_file
+KAFKA_BROKERS:
+  - kafka-1:9092
+  - kafka-2:9092
+KAFKA_GROUP_ID: from-file
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Empty values in .env, as in .env.example, leave the setting to the file
+	err = os.WriteFile(filepath.Join(dir, ".env"), []byte(`CONFIG_FILE=`+file+`
+TOKEN_KEY=test-key
+JWT_PUBLIC_KEY_FILE=
+HTTP_PORT=:9004
+MONGO_DATABASE=
+KAFKA_GROUP_ID=from-dotenv
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("GRPC_PORT", ":9001")
+	os.Setenv("KAFKA_GROUP_ID", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		setting   string
+		got, want interface{}
+	}{
+		{"environment over .env and file", cfg.GRPCPort, ":9001"},
+		{".env over file", cfg.HTTPPort, ":9004"},
+		{"file over empty .env value", cfg.MongoDatabase, "from_file"},
+		{"empty environment value over nothing", cfg.KafkaGroupID, "from-dotenv"},
+		{"file list", cfg.KafkaBrokers, []string{"kafka-1:9092", "kafka-2:9092"}},
+		{"default", cfg.KafkaTopic, "create"},
+		{"default duration", cfg.ShutdownTimeout, 30 * time.Second},
+		{"default for empty .env value", cfg.JWTPublicKeyFile, ""},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.setting, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package dates holds the day format and time zone handling shared by the API
+// validation, the services and storage.
+package dates
+
+import "time"
+
+// Layout is the format of day-keyed data such as exchange rates and statement
+// dates. Points in time are timestamps instead.
+const Layout = "2006-01-02"
+
+// Location loads an IANA time zone, falling back to UTC for unknown or empty zones
+func Location(zone string) *time.Location {
+	if zone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
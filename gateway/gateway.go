@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"strings"
+
+	pb "budget-service/genproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// requestIDHeader is passed through both ways, like the gRPC server does
+const requestIDHeader = "x-request-id"
+
+//go:embed openapi.swagger.json
+var openAPI []byte
+
+// Gateway serves the REST API described in http.yaml by calling the gRPC server,
+// so calls go through the same authentication, access policy and validation.
+// Bodies and responses are JSON with the proto field names.
+type Gateway struct {
+	conn *grpc.ClientConn
+	mux  *runtime.ServeMux
+}
+
+// New returns a Gateway calling the gRPC server at endpoint, e.g. localhost:8088
+func New(endpoint string) (*Gateway, error) {
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+	)
+
+	ctx := context.Background()
+	for _, register := range []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		pb.RegisterAccountServiceHandler,
+		pb.RegisterBudgetServiceHandler,
+		pb.RegisterCategoryServiceHandler,
+		pb.RegisterGoalServiceHandler,
+		pb.RegisterTransactionServiceHandler,
+		pb.RegisterNotificationtServiceHandler,
+		pb.RegisterReportServiceHandler,
+	} {
+		if err := register(ctx, mux, conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	err = mux.HandlePath(http.MethodGet, "/openapi.json", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Gateway{conn: conn, mux: mux}, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// Close closes the connection to the gRPC server
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// incomingHeader forwards the request id besides the headers the gateway forwards
+// by default, like Authorization
+func incomingHeader(key string) (string, bool) {
+	if strings.ToLower(key) == requestIDHeader {
+		return requestIDHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader returns the request id as it came in; other gRPC headers keep
+// the Grpc-Metadata- prefix
+func outgoingHeader(key string) (string, bool) {
+	if key == requestIDHeader {
+		return http.CanonicalHeaderKey(requestIDHeader), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -27,6 +27,10 @@ type AccountServiceClient interface {
 	GetAccountById(ctx context.Context, in *GetAccountByIdRequest, opts ...grpc.CallOption) (*AccountResponse, error)
 	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	ArchiveAccount(ctx context.Context, in *ArchiveAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error)
+	ReopenAccount(ctx context.Context, in *ReopenAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error)
+	RestoreAccount(ctx context.Context, in *RestoreAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error)
+	RecalculateBalances(ctx context.Context, in *RecalculateBalancesRequest, opts ...grpc.CallOption) (*RecalculateBalancesResponse, error)
 }
 
 type accountServiceClient struct {
@@ -82,6 +86,42 @@ func (c *accountServiceClient) DeleteAccount(ctx context.Context, in *DeleteAcco
 	return out, nil
 }
 
+func (c *accountServiceClient) ArchiveAccount(ctx context.Context, in *ArchiveAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error) {
+	out := new(CreateAccountRes)
+	err := c.cc.Invoke(ctx, "/budget.AccountService/ArchiveAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ReopenAccount(ctx context.Context, in *ReopenAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error) {
+	out := new(CreateAccountRes)
+	err := c.cc.Invoke(ctx, "/budget.AccountService/ReopenAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) RestoreAccount(ctx context.Context, in *RestoreAccountRequest, opts ...grpc.CallOption) (*CreateAccountRes, error) {
+	out := new(CreateAccountRes)
+	err := c.cc.Invoke(ctx, "/budget.AccountService/RestoreAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) RecalculateBalances(ctx context.Context, in *RecalculateBalancesRequest, opts ...grpc.CallOption) (*RecalculateBalancesResponse, error) {
+	out := new(RecalculateBalancesResponse)
+	err := c.cc.Invoke(ctx, "/budget.AccountService/RecalculateBalances", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServiceServer is the server API for AccountService service.
 // All implementations must embed UnimplementedAccountServiceServer
 // for forward compatibility
@@ -91,6 +131,10 @@ type AccountServiceServer interface {
 	GetAccountById(context.Context, *GetAccountByIdRequest) (*AccountResponse, error)
 	UpdateAccount(context.Context, *UpdateAccountRequest) (*CreateAccountRes, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteResponse, error)
+	ArchiveAccount(context.Context, *ArchiveAccountRequest) (*CreateAccountRes, error)
+	ReopenAccount(context.Context, *ReopenAccountRequest) (*CreateAccountRes, error)
+	RestoreAccount(context.Context, *RestoreAccountRequest) (*CreateAccountRes, error)
+	RecalculateBalances(context.Context, *RecalculateBalancesRequest) (*RecalculateBalancesResponse, error)
 	mustEmbedUnimplementedAccountServiceServer()
 }
 
@@ -113,6 +157,18 @@ func (UnimplementedAccountServiceServer) UpdateAccount(context.Context, *UpdateA
 func (UnimplementedAccountServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
 }
+func (UnimplementedAccountServiceServer) ArchiveAccount(context.Context, *ArchiveAccountRequest) (*CreateAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveAccount not implemented")
+}
+func (UnimplementedAccountServiceServer) ReopenAccount(context.Context, *ReopenAccountRequest) (*CreateAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReopenAccount not implemented")
+}
+func (UnimplementedAccountServiceServer) RestoreAccount(context.Context, *RestoreAccountRequest) (*CreateAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAccount not implemented")
+}
+func (UnimplementedAccountServiceServer) RecalculateBalances(context.Context, *RecalculateBalancesRequest) (*RecalculateBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecalculateBalances not implemented")
+}
 func (UnimplementedAccountServiceServer) mustEmbedUnimplementedAccountServiceServer() {}
 
 // UnsafeAccountServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +272,78 @@ func _AccountService_DeleteAccount_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_ArchiveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ArchiveAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.AccountService/ArchiveAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ArchiveAccount(ctx, req.(*ArchiveAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ReopenAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReopenAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ReopenAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.AccountService/ReopenAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ReopenAccount(ctx, req.(*ReopenAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_RestoreAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).RestoreAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.AccountService/RestoreAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).RestoreAccount(ctx, req.(*RestoreAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_RecalculateBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecalculateBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).RecalculateBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.AccountService/RecalculateBalances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).RecalculateBalances(ctx, req.(*RecalculateBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AccountService_ServiceDesc is the grpc.ServiceDesc for AccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +371,22 @@ var AccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAccount",
 			Handler:    _AccountService_DeleteAccount_Handler,
 		},
+		{
+			MethodName: "ArchiveAccount",
+			Handler:    _AccountService_ArchiveAccount_Handler,
+		},
+		{
+			MethodName: "ReopenAccount",
+			Handler:    _AccountService_ReopenAccount_Handler,
+		},
+		{
+			MethodName: "RestoreAccount",
+			Handler:    _AccountService_RestoreAccount_Handler,
+		},
+		{
+			MethodName: "RecalculateBalances",
+			Handler:    _AccountService_RecalculateBalances_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "account_managment.proto",
@@ -27,6 +27,7 @@ type BudgetServiceClient interface {
 	GetBudgetById(ctx context.Context, in *GetBudgetByIdRequest, opts ...grpc.CallOption) (*BudgetResponse, error)
 	UpdateBudget(ctx context.Context, in *UpdateBudgetRequest, opts ...grpc.CallOption) (*MessageResponsee, error)
 	DeleteBudget(ctx context.Context, in *DeleteBudgetRequest, opts ...grpc.CallOption) (*BudgetDeleteResponse, error)
+	RestoreBudget(ctx context.Context, in *RestoreBudgetRequest, opts ...grpc.CallOption) (*MessageResponsee, error)
 }
 
 type budgetServiceClient struct {
@@ -82,6 +83,15 @@ func (c *budgetServiceClient) DeleteBudget(ctx context.Context, in *DeleteBudget
 	return out, nil
 }
 
+func (c *budgetServiceClient) RestoreBudget(ctx context.Context, in *RestoreBudgetRequest, opts ...grpc.CallOption) (*MessageResponsee, error) {
+	out := new(MessageResponsee)
+	err := c.cc.Invoke(ctx, "/budget.BudgetService/RestoreBudget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BudgetServiceServer is the server API for BudgetService service.
 // All implementations must embed UnimplementedBudgetServiceServer
 // for forward compatibility
@@ -91,6 +101,7 @@ type BudgetServiceServer interface {
 	GetBudgetById(context.Context, *GetBudgetByIdRequest) (*BudgetResponse, error)
 	UpdateBudget(context.Context, *UpdateBudgetRequest) (*MessageResponsee, error)
 	DeleteBudget(context.Context, *DeleteBudgetRequest) (*BudgetDeleteResponse, error)
+	RestoreBudget(context.Context, *RestoreBudgetRequest) (*MessageResponsee, error)
 	mustEmbedUnimplementedBudgetServiceServer()
 }
 
@@ -113,6 +124,9 @@ func (UnimplementedBudgetServiceServer) UpdateBudget(context.Context, *UpdateBud
 func (UnimplementedBudgetServiceServer) DeleteBudget(context.Context, *DeleteBudgetRequest) (*BudgetDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBudget not implemented")
 }
+func (UnimplementedBudgetServiceServer) RestoreBudget(context.Context, *RestoreBudgetRequest) (*MessageResponsee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBudget not implemented")
+}
 func (UnimplementedBudgetServiceServer) mustEmbedUnimplementedBudgetServiceServer() {}
 
 // UnsafeBudgetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +230,24 @@ func _BudgetService_DeleteBudget_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BudgetService_RestoreBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BudgetServiceServer).RestoreBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.BudgetService/RestoreBudget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BudgetServiceServer).RestoreBudget(ctx, req.(*RestoreBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BudgetService_ServiceDesc is the grpc.ServiceDesc for BudgetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +275,10 @@ var BudgetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBudget",
 			Handler:    _BudgetService_DeleteBudget_Handler,
 		},
+		{
+			MethodName: "RestoreBudget",
+			Handler:    _BudgetService_RestoreBudget_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "budget_managment.proto",
@@ -27,6 +27,9 @@ type CategoryServiceClient interface {
 	GetCategoryById(ctx context.Context, in *GetCategoryByIdRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
 	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*MessageResponse, error)
 	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*CategoryDeleteResponse, error)
+	RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	ListCategoryTemplates(ctx context.Context, in *ListCategoryTemplatesRequest, opts ...grpc.CallOption) (*ListCategoryTemplatesResponse, error)
+	SeedDefaultCategories(ctx context.Context, in *SeedDefaultCategoriesRequest, opts ...grpc.CallOption) (*SeedDefaultCategoriesResponse, error)
 }
 
 type categoryServiceClient struct {
@@ -82,6 +85,33 @@ func (c *categoryServiceClient) DeleteCategory(ctx context.Context, in *DeleteCa
 	return out, nil
 }
 
+func (c *categoryServiceClient) RestoreCategory(ctx context.Context, in *RestoreCategoryRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, "/budget.CategoryService/RestoreCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) ListCategoryTemplates(ctx context.Context, in *ListCategoryTemplatesRequest, opts ...grpc.CallOption) (*ListCategoryTemplatesResponse, error) {
+	out := new(ListCategoryTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/budget.CategoryService/ListCategoryTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) SeedDefaultCategories(ctx context.Context, in *SeedDefaultCategoriesRequest, opts ...grpc.CallOption) (*SeedDefaultCategoriesResponse, error) {
+	out := new(SeedDefaultCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/budget.CategoryService/SeedDefaultCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CategoryServiceServer is the server API for CategoryService service.
 // All implementations must embed UnimplementedCategoryServiceServer
 // for forward compatibility
@@ -91,6 +121,9 @@ type CategoryServiceServer interface {
 	GetCategoryById(context.Context, *GetCategoryByIdRequest) (*CategoryResponse, error)
 	UpdateCategory(context.Context, *UpdateCategoryRequest) (*MessageResponse, error)
 	DeleteCategory(context.Context, *DeleteCategoryRequest) (*CategoryDeleteResponse, error)
+	RestoreCategory(context.Context, *RestoreCategoryRequest) (*MessageResponse, error)
+	ListCategoryTemplates(context.Context, *ListCategoryTemplatesRequest) (*ListCategoryTemplatesResponse, error)
+	SeedDefaultCategories(context.Context, *SeedDefaultCategoriesRequest) (*SeedDefaultCategoriesResponse, error)
 	mustEmbedUnimplementedCategoryServiceServer()
 }
 
@@ -113,6 +146,15 @@ func (UnimplementedCategoryServiceServer) UpdateCategory(context.Context, *Updat
 func (UnimplementedCategoryServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*CategoryDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
 }
+func (UnimplementedCategoryServiceServer) RestoreCategory(context.Context, *RestoreCategoryRequest) (*MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCategory not implemented")
+}
+func (UnimplementedCategoryServiceServer) ListCategoryTemplates(context.Context, *ListCategoryTemplatesRequest) (*ListCategoryTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategoryTemplates not implemented")
+}
+func (UnimplementedCategoryServiceServer) SeedDefaultCategories(context.Context, *SeedDefaultCategoriesRequest) (*SeedDefaultCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeedDefaultCategories not implemented")
+}
 func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
 
 // UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +258,60 @@ func _CategoryService_DeleteCategory_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CategoryService_RestoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.CategoryService/RestoreCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).RestoreCategory(ctx, req.(*RestoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_ListCategoryTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).ListCategoryTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.CategoryService/ListCategoryTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).ListCategoryTemplates(ctx, req.(*ListCategoryTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_SeedDefaultCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeedDefaultCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).SeedDefaultCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.CategoryService/SeedDefaultCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).SeedDefaultCategories(ctx, req.(*SeedDefaultCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +339,18 @@ var CategoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCategory",
 			Handler:    _CategoryService_DeleteCategory_Handler,
 		},
+		{
+			MethodName: "RestoreCategory",
+			Handler:    _CategoryService_RestoreCategory_Handler,
+		},
+		{
+			MethodName: "ListCategoryTemplates",
+			Handler:    _CategoryService_ListCategoryTemplates_Handler,
+		},
+		{
+			MethodName: "SeedDefaultCategories",
+			Handler:    _CategoryService_SeedDefaultCategories_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "category_managment.proto",
@@ -0,0 +1,706 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: debt_payoff.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlanDebtPayoffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId        string  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MonthlyBudget float64 `protobuf:"fixed64,2,opt,name=monthly_budget,json=monthlyBudget,proto3" json:"monthly_budget,omitempty"`
+}
+
+func (x *PlanDebtPayoffRequest) Reset() {
+	*x = PlanDebtPayoffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debt_payoff_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanDebtPayoffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDebtPayoffRequest) ProtoMessage() {}
+
+func (x *PlanDebtPayoffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_debt_payoff_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDebtPayoffRequest.ProtoReflect.Descriptor instead.
+func (*PlanDebtPayoffRequest) Descriptor() ([]byte, []int) {
+	return file_debt_payoff_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlanDebtPayoffRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlanDebtPayoffRequest) GetMonthlyBudget() float64 {
+	if x != nil {
+		return x.MonthlyBudget
+	}
+	return 0
+}
+
+type DebtPayment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountId        string  `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Payment          float64 `protobuf:"fixed64,2,opt,name=payment,proto3" json:"payment,omitempty"`
+	Interest         float64 `protobuf:"fixed64,3,opt,name=interest,proto3" json:"interest,omitempty"`
+	RemainingBalance float64 `protobuf:"fixed64,4,opt,name=remaining_balance,json=remainingBalance,proto3" json:"remaining_balance,omitempty"`
+}
+
+func (x *DebtPayment) Reset() {
+	*x = DebtPayment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debt_payoff_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebtPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebtPayment) ProtoMessage() {}
+
+func (x *DebtPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_debt_payoff_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebtPayment.ProtoReflect.Descriptor instead.
+func (*DebtPayment) Descriptor() ([]byte, []int) {
+	return file_debt_payoff_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DebtPayment) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DebtPayment) GetPayment() float64 {
+	if x != nil {
+		return x.Payment
+	}
+	return 0
+}
+
+func (x *DebtPayment) GetInterest() float64 {
+	if x != nil {
+		return x.Interest
+	}
+	return 0
+}
+
+func (x *DebtPayment) GetRemainingBalance() float64 {
+	if x != nil {
+		return x.RemainingBalance
+	}
+	return 0
+}
+
+type PayoffMonth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Month     int32          `protobuf:"varint,1,opt,name=month,proto3" json:"month,omitempty"`
+	Date      string         `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Payments  []*DebtPayment `protobuf:"bytes,3,rep,name=payments,proto3" json:"payments,omitempty"`
+	TotalPaid float64        `protobuf:"fixed64,4,opt,name=total_paid,json=totalPaid,proto3" json:"total_paid,omitempty"`
+}
+
+func (x *PayoffMonth) Reset() {
+	*x = PayoffMonth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debt_payoff_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PayoffMonth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoffMonth) ProtoMessage() {}
+
+func (x *PayoffMonth) ProtoReflect() protoreflect.Message {
+	mi := &file_debt_payoff_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoffMonth.ProtoReflect.Descriptor instead.
+func (*PayoffMonth) Descriptor() ([]byte, []int) {
+	return file_debt_payoff_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PayoffMonth) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *PayoffMonth) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *PayoffMonth) GetPayments() []*DebtPayment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *PayoffMonth) GetTotalPaid() float64 {
+	if x != nil {
+		return x.TotalPaid
+	}
+	return 0
+}
+
+type DebtSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountId       string  `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountName     string  `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	StartingBalance float64 `protobuf:"fixed64,3,opt,name=starting_balance,json=startingBalance,proto3" json:"starting_balance,omitempty"`
+	Apr             float64 `protobuf:"fixed64,4,opt,name=apr,proto3" json:"apr,omitempty"`
+	InterestPaid    float64 `protobuf:"fixed64,5,opt,name=interest_paid,json=interestPaid,proto3" json:"interest_paid,omitempty"`
+	MonthsToPayoff  int32   `protobuf:"varint,6,opt,name=months_to_payoff,json=monthsToPayoff,proto3" json:"months_to_payoff,omitempty"`
+	PayoffDate      string  `protobuf:"bytes,7,opt,name=payoff_date,json=payoffDate,proto3" json:"payoff_date,omitempty"`
+}
+
+func (x *DebtSummary) Reset() {
+	*x = DebtSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debt_payoff_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebtSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebtSummary) ProtoMessage() {}
+
+func (x *DebtSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_debt_payoff_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebtSummary.ProtoReflect.Descriptor instead.
+func (*DebtSummary) Descriptor() ([]byte, []int) {
+	return file_debt_payoff_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DebtSummary) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DebtSummary) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *DebtSummary) GetStartingBalance() float64 {
+	if x != nil {
+		return x.StartingBalance
+	}
+	return 0
+}
+
+func (x *DebtSummary) GetApr() float64 {
+	if x != nil {
+		return x.Apr
+	}
+	return 0
+}
+
+func (x *DebtSummary) GetInterestPaid() float64 {
+	if x != nil {
+		return x.InterestPaid
+	}
+	return 0
+}
+
+func (x *DebtSummary) GetMonthsToPayoff() int32 {
+	if x != nil {
+		return x.MonthsToPayoff
+	}
+	return 0
+}
+
+func (x *DebtSummary) GetPayoffDate() string {
+	if x != nil {
+		return x.PayoffDate
+	}
+	return ""
+}
+
+type PayoffPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Strategy      string         `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Feasible      bool           `protobuf:"varint,2,opt,name=feasible,proto3" json:"feasible,omitempty"`
+	Months        int32          `protobuf:"varint,3,opt,name=months,proto3" json:"months,omitempty"`
+	PayoffDate    string         `protobuf:"bytes,4,opt,name=payoff_date,json=payoffDate,proto3" json:"payoff_date,omitempty"`
+	TotalInterest float64        `protobuf:"fixed64,5,opt,name=total_interest,json=totalInterest,proto3" json:"total_interest,omitempty"`
+	TotalPaid     float64        `protobuf:"fixed64,6,opt,name=total_paid,json=totalPaid,proto3" json:"total_paid,omitempty"`
+	Debts         []*DebtSummary `protobuf:"bytes,7,rep,name=debts,proto3" json:"debts,omitempty"`
+	Schedule      []*PayoffMonth `protobuf:"bytes,8,rep,name=schedule,proto3" json:"schedule,omitempty"`
+}
+
+func (x *PayoffPlan) Reset() {
+	*x = PayoffPlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debt_payoff_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PayoffPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoffPlan) ProtoMessage() {}
+
+func (x *PayoffPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_debt_payoff_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoffPlan.ProtoReflect.Descriptor instead.
+func (*PayoffPlan) Descriptor() ([]byte, []int) {
+	return file_debt_payoff_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PayoffPlan) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *PayoffPlan) GetFeasible() bool {
+	if x != nil {
+		return x.Feasible
+	}
+	return false
+}
+
+func (x *PayoffPlan) GetMonths() int32 {
+	if x != nil {
+		return x.Months
+	}
+	return 0
+}
+
+func (x *PayoffPlan) GetPayoffDate() string {
+	if x != nil {
+		return x.PayoffDate
+	}
+	return ""
+}
+
+func (x *PayoffPlan) GetTotalInterest() float64 {
+	if x != nil {
+		return x.TotalInterest
+	}
+	return 0
+}
+
+func (x *PayoffPlan) GetTotalPaid() float64 {
+	if x != nil {
+		return x.TotalPaid
+	}
+	return 0
+}
+
+func (x *PayoffPlan) GetDebts() []*DebtSummary {
+	if x != nil {
+		return x.Debts
+	}
+	return nil
+}
+
+func (x *PayoffPlan) GetSchedule() []*PayoffMonth {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type PlanDebtPayoffResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Currency        string      `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	MinimumPayments float64     `protobuf:"fixed64,2,opt,name=minimum_payments,json=minimumPayments,proto3" json:"minimum_payments,omitempty"`
+	Avalanche       *PayoffPlan `protobuf:"bytes,3,opt,name=avalanche,proto3" json:"avalanche,omitempty"`
+	Snowball        *PayoffPlan `protobuf:"bytes,4,opt,name=snowball,proto3" json:"snowball,omitempty"`
+}
+
+func (x *PlanDebtPayoffResponse) Reset() {
+	*x = PlanDebtPayoffResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_debt_payoff_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanDebtPayoffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDebtPayoffResponse) ProtoMessage() {}
+
+func (x *PlanDebtPayoffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_debt_payoff_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDebtPayoffResponse.ProtoReflect.Descriptor instead.
+func (*PlanDebtPayoffResponse) Descriptor() ([]byte, []int) {
+	return file_debt_payoff_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlanDebtPayoffResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PlanDebtPayoffResponse) GetMinimumPayments() float64 {
+	if x != nil {
+		return x.MinimumPayments
+	}
+	return 0
+}
+
+func (x *PlanDebtPayoffResponse) GetAvalanche() *PayoffPlan {
+	if x != nil {
+		return x.Avalanche
+	}
+	return nil
+}
+
+func (x *PlanDebtPayoffResponse) GetSnowball() *PayoffPlan {
+	if x != nil {
+		return x.Snowball
+	}
+	return nil
+}
+
+var File_debt_payoff_proto protoreflect.FileDescriptor
+
+var file_debt_payoff_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x64, 0x65, 0x62, 0x74, 0x5f, 0x70, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x22, 0x57, 0x0a, 0x15, 0x50,
+	0x6c, 0x61, 0x6e, 0x44, 0x65, 0x62, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x42, 0x75,
+	0x64, 0x67, 0x65, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x62, 0x74, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x0b, 0x50, 0x61, 0x79, 0x6f, 0x66,
+	0x66, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x2f, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e, 0x44, 0x65, 0x62, 0x74,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x69, 0x64,
+	0x22, 0xfc, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x62, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x61, 0x70, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x70, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74,
+	0x50, 0x61, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x5f, 0x74,
+	0x6f, 0x5f, 0x70, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x54, 0x6f, 0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x44, 0x61, 0x74, 0x65, 0x22,
+	0x9f, 0x02, 0x0a, 0x0a, 0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65,
+	0x61, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x65,
+	0x61, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x70, 0x61, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x50, 0x61, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x74, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e, 0x44, 0x65,
+	0x62, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x05, 0x64, 0x65, 0x62, 0x74, 0x73,
+	0x12, 0x2f, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e, 0x50, 0x61, 0x79, 0x6f,
+	0x66, 0x66, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x22, 0xc1, 0x01, 0x0a, 0x16, 0x50, 0x6c, 0x61, 0x6e, 0x44, 0x65, 0x62, 0x74, 0x50, 0x61,
+	0x79, 0x6f, 0x66, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x69,
+	0x6d, 0x75, 0x6d, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e,
+	0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x09, 0x61, 0x76, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x68, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x73, 0x6e, 0x6f, 0x77, 0x62, 0x61, 0x6c,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74,
+	0x2e, 0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x08, 0x73, 0x6e, 0x6f,
+	0x77, 0x62, 0x61, 0x6c, 0x6c, 0x32, 0x5e, 0x0a, 0x0b, 0x44, 0x65, 0x62, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x6e, 0x44, 0x65, 0x62, 0x74,
+	0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x12, 0x1d, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e,
+	0x50, 0x6c, 0x61, 0x6e, 0x44, 0x65, 0x62, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e, 0x50,
+	0x6c, 0x61, 0x6e, 0x44, 0x65, 0x62, 0x74, 0x50, 0x61, 0x79, 0x6f, 0x66, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0b, 0x5a, 0x09, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_debt_payoff_proto_rawDescOnce sync.Once
+	file_debt_payoff_proto_rawDescData = file_debt_payoff_proto_rawDesc
+)
+
+func file_debt_payoff_proto_rawDescGZIP() []byte {
+	file_debt_payoff_proto_rawDescOnce.Do(func() {
+		file_debt_payoff_proto_rawDescData = protoimpl.X.CompressGZIP(file_debt_payoff_proto_rawDescData)
+	})
+	return file_debt_payoff_proto_rawDescData
+}
+
+var file_debt_payoff_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_debt_payoff_proto_goTypes = []interface{}{
+	(*PlanDebtPayoffRequest)(nil),  // 0: budget.PlanDebtPayoffRequest
+	(*DebtPayment)(nil),            // 1: budget.DebtPayment
+	(*PayoffMonth)(nil),            // 2: budget.PayoffMonth
+	(*DebtSummary)(nil),            // 3: budget.DebtSummary
+	(*PayoffPlan)(nil),             // 4: budget.PayoffPlan
+	(*PlanDebtPayoffResponse)(nil), // 5: budget.PlanDebtPayoffResponse
+}
+var file_debt_payoff_proto_depIdxs = []int32{
+	1, // 0: budget.PayoffMonth.payments:type_name -> budget.DebtPayment
+	3, // 1: budget.PayoffPlan.debts:type_name -> budget.DebtSummary
+	2, // 2: budget.PayoffPlan.schedule:type_name -> budget.PayoffMonth
+	4, // 3: budget.PlanDebtPayoffResponse.avalanche:type_name -> budget.PayoffPlan
+	4, // 4: budget.PlanDebtPayoffResponse.snowball:type_name -> budget.PayoffPlan
+	0, // 5: budget.DebtService.PlanDebtPayoff:input_type -> budget.PlanDebtPayoffRequest
+	5, // 6: budget.DebtService.PlanDebtPayoff:output_type -> budget.PlanDebtPayoffResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_debt_payoff_proto_init() }
+func file_debt_payoff_proto_init() {
+	if File_debt_payoff_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_debt_payoff_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanDebtPayoffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debt_payoff_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebtPayment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debt_payoff_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PayoffMonth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debt_payoff_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebtSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debt_payoff_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PayoffPlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_debt_payoff_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanDebtPayoffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_debt_payoff_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_debt_payoff_proto_goTypes,
+		DependencyIndexes: file_debt_payoff_proto_depIdxs,
+		MessageInfos:      file_debt_payoff_proto_msgTypes,
+	}.Build()
+	File_debt_payoff_proto = out.File
+	file_debt_payoff_proto_rawDesc = nil
+	file_debt_payoff_proto_goTypes = nil
+	file_debt_payoff_proto_depIdxs = nil
+}
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: debt_payoff.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// DebtServiceClient is the client API for DebtService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DebtServiceClient interface {
+	PlanDebtPayoff(ctx context.Context, in *PlanDebtPayoffRequest, opts ...grpc.CallOption) (*PlanDebtPayoffResponse, error)
+}
+
+type debtServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDebtServiceClient(cc grpc.ClientConnInterface) DebtServiceClient {
+	return &debtServiceClient{cc}
+}
+
+func (c *debtServiceClient) PlanDebtPayoff(ctx context.Context, in *PlanDebtPayoffRequest, opts ...grpc.CallOption) (*PlanDebtPayoffResponse, error) {
+	out := new(PlanDebtPayoffResponse)
+	err := c.cc.Invoke(ctx, "/budget.DebtService/PlanDebtPayoff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DebtServiceServer is the server API for DebtService service.
+// All implementations must embed UnimplementedDebtServiceServer
+// for forward compatibility
+type DebtServiceServer interface {
+	PlanDebtPayoff(context.Context, *PlanDebtPayoffRequest) (*PlanDebtPayoffResponse, error)
+	mustEmbedUnimplementedDebtServiceServer()
+}
+
+// UnimplementedDebtServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDebtServiceServer struct {
+}
+
+func (UnimplementedDebtServiceServer) PlanDebtPayoff(context.Context, *PlanDebtPayoffRequest) (*PlanDebtPayoffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanDebtPayoff not implemented")
+}
+func (UnimplementedDebtServiceServer) mustEmbedUnimplementedDebtServiceServer() {}
+
+// UnsafeDebtServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DebtServiceServer will
+// result in compilation errors.
+type UnsafeDebtServiceServer interface {
+	mustEmbedUnimplementedDebtServiceServer()
+}
+
+func RegisterDebtServiceServer(s grpc.ServiceRegistrar, srv DebtServiceServer) {
+	s.RegisterService(&DebtService_ServiceDesc, srv)
+}
+
+func _DebtService_PlanDebtPayoff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanDebtPayoffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DebtServiceServer).PlanDebtPayoff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.DebtService/PlanDebtPayoff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DebtServiceServer).PlanDebtPayoff(ctx, req.(*PlanDebtPayoffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DebtService_ServiceDesc is the grpc.ServiceDesc for DebtService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DebtService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.DebtService",
+	HandlerType: (*DebtServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlanDebtPayoff",
+			Handler:    _DebtService_PlanDebtPayoff_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "debt_payoff.proto",
+}
@@ -0,0 +1,177 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: exchange_rate.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExchangeRateServiceClient is the client API for ExchangeRateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExchangeRateServiceClient interface {
+	SetExchangeRate(ctx context.Context, in *ExchangeRate, opts ...grpc.CallOption) (*ExchangeRateResponse, error)
+	ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error)
+	ConvertAmount(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error)
+}
+
+type exchangeRateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExchangeRateServiceClient(cc grpc.ClientConnInterface) ExchangeRateServiceClient {
+	return &exchangeRateServiceClient{cc}
+}
+
+func (c *exchangeRateServiceClient) SetExchangeRate(ctx context.Context, in *ExchangeRate, opts ...grpc.CallOption) (*ExchangeRateResponse, error) {
+	out := new(ExchangeRateResponse)
+	err := c.cc.Invoke(ctx, "/budget.ExchangeRateService/SetExchangeRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeRateServiceClient) ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error) {
+	out := new(ListExchangeRatesResponse)
+	err := c.cc.Invoke(ctx, "/budget.ExchangeRateService/ListExchangeRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exchangeRateServiceClient) ConvertAmount(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error) {
+	out := new(ConvertAmountResponse)
+	err := c.cc.Invoke(ctx, "/budget.ExchangeRateService/ConvertAmount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExchangeRateServiceServer is the server API for ExchangeRateService service.
+// All implementations must embed UnimplementedExchangeRateServiceServer
+// for forward compatibility
+type ExchangeRateServiceServer interface {
+	SetExchangeRate(context.Context, *ExchangeRate) (*ExchangeRateResponse, error)
+	ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error)
+	ConvertAmount(context.Context, *ConvertAmountRequest) (*ConvertAmountResponse, error)
+	mustEmbedUnimplementedExchangeRateServiceServer()
+}
+
+// UnimplementedExchangeRateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExchangeRateServiceServer struct {
+}
+
+func (UnimplementedExchangeRateServiceServer) SetExchangeRate(context.Context, *ExchangeRate) (*ExchangeRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExchangeRate not implemented")
+}
+func (UnimplementedExchangeRateServiceServer) ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExchangeRates not implemented")
+}
+func (UnimplementedExchangeRateServiceServer) ConvertAmount(context.Context, *ConvertAmountRequest) (*ConvertAmountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertAmount not implemented")
+}
+func (UnimplementedExchangeRateServiceServer) mustEmbedUnimplementedExchangeRateServiceServer() {}
+
+// UnsafeExchangeRateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExchangeRateServiceServer will
+// result in compilation errors.
+type UnsafeExchangeRateServiceServer interface {
+	mustEmbedUnimplementedExchangeRateServiceServer()
+}
+
+func RegisterExchangeRateServiceServer(s grpc.ServiceRegistrar, srv ExchangeRateServiceServer) {
+	s.RegisterService(&ExchangeRateService_ServiceDesc, srv)
+}
+
+func _ExchangeRateService_SetExchangeRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeRateServiceServer).SetExchangeRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ExchangeRateService/SetExchangeRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeRateServiceServer).SetExchangeRate(ctx, req.(*ExchangeRate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeRateService_ListExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExchangeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeRateServiceServer).ListExchangeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ExchangeRateService/ListExchangeRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeRateServiceServer).ListExchangeRates(ctx, req.(*ListExchangeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExchangeRateService_ConvertAmount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertAmountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExchangeRateServiceServer).ConvertAmount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ExchangeRateService/ConvertAmount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExchangeRateServiceServer).ConvertAmount(ctx, req.(*ConvertAmountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExchangeRateService_ServiceDesc is the grpc.ServiceDesc for ExchangeRateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExchangeRateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.ExchangeRateService",
+	HandlerType: (*ExchangeRateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetExchangeRate",
+			Handler:    _ExchangeRateService_SetExchangeRate_Handler,
+		},
+		{
+			MethodName: "ListExchangeRates",
+			Handler:    _ExchangeRateService_ListExchangeRates_Handler,
+		},
+		{
+			MethodName: "ConvertAmount",
+			Handler:    _ExchangeRateService_ConvertAmount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "exchange_rate.proto",
+}
@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: expense_split.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SplitServiceClient is the client API for SplitService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SplitServiceClient interface {
+	CreateSplitExpense(ctx context.Context, in *CreateSplitExpenseRequest, opts ...grpc.CallOption) (*SplitExpense, error)
+	ListSplitExpenses(ctx context.Context, in *ListSplitExpensesRequest, opts ...grpc.CallOption) (*ListSplitExpensesResponse, error)
+	GetIous(ctx context.Context, in *GetIousRequest, opts ...grpc.CallOption) (*IousResponse, error)
+	// SimplifyDebts nets what the household's members owe each other into a shorter list of transfers
+	SimplifyDebts(ctx context.Context, in *SimplifyDebtsRequest, opts ...grpc.CallOption) (*IousResponse, error)
+	RecordSettlement(ctx context.Context, in *RecordSettlementRequest, opts ...grpc.CallOption) (*Settlement, error)
+}
+
+type splitServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSplitServiceClient(cc grpc.ClientConnInterface) SplitServiceClient {
+	return &splitServiceClient{cc}
+}
+
+func (c *splitServiceClient) CreateSplitExpense(ctx context.Context, in *CreateSplitExpenseRequest, opts ...grpc.CallOption) (*SplitExpense, error) {
+	out := new(SplitExpense)
+	err := c.cc.Invoke(ctx, "/budget.SplitService/CreateSplitExpense", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *splitServiceClient) ListSplitExpenses(ctx context.Context, in *ListSplitExpensesRequest, opts ...grpc.CallOption) (*ListSplitExpensesResponse, error) {
+	out := new(ListSplitExpensesResponse)
+	err := c.cc.Invoke(ctx, "/budget.SplitService/ListSplitExpenses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *splitServiceClient) GetIous(ctx context.Context, in *GetIousRequest, opts ...grpc.CallOption) (*IousResponse, error) {
+	out := new(IousResponse)
+	err := c.cc.Invoke(ctx, "/budget.SplitService/GetIous", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *splitServiceClient) SimplifyDebts(ctx context.Context, in *SimplifyDebtsRequest, opts ...grpc.CallOption) (*IousResponse, error) {
+	out := new(IousResponse)
+	err := c.cc.Invoke(ctx, "/budget.SplitService/SimplifyDebts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *splitServiceClient) RecordSettlement(ctx context.Context, in *RecordSettlementRequest, opts ...grpc.CallOption) (*Settlement, error) {
+	out := new(Settlement)
+	err := c.cc.Invoke(ctx, "/budget.SplitService/RecordSettlement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SplitServiceServer is the server API for SplitService service.
+// All implementations must embed UnimplementedSplitServiceServer
+// for forward compatibility
+type SplitServiceServer interface {
+	CreateSplitExpense(context.Context, *CreateSplitExpenseRequest) (*SplitExpense, error)
+	ListSplitExpenses(context.Context, *ListSplitExpensesRequest) (*ListSplitExpensesResponse, error)
+	GetIous(context.Context, *GetIousRequest) (*IousResponse, error)
+	// SimplifyDebts nets what the household's members owe each other into a shorter list of transfers
+	SimplifyDebts(context.Context, *SimplifyDebtsRequest) (*IousResponse, error)
+	RecordSettlement(context.Context, *RecordSettlementRequest) (*Settlement, error)
+	mustEmbedUnimplementedSplitServiceServer()
+}
+
+// UnimplementedSplitServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSplitServiceServer struct {
+}
+
+func (UnimplementedSplitServiceServer) CreateSplitExpense(context.Context, *CreateSplitExpenseRequest) (*SplitExpense, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSplitExpense not implemented")
+}
+func (UnimplementedSplitServiceServer) ListSplitExpenses(context.Context, *ListSplitExpensesRequest) (*ListSplitExpensesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSplitExpenses not implemented")
+}
+func (UnimplementedSplitServiceServer) GetIous(context.Context, *GetIousRequest) (*IousResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIous not implemented")
+}
+func (UnimplementedSplitServiceServer) SimplifyDebts(context.Context, *SimplifyDebtsRequest) (*IousResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimplifyDebts not implemented")
+}
+func (UnimplementedSplitServiceServer) RecordSettlement(context.Context, *RecordSettlementRequest) (*Settlement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordSettlement not implemented")
+}
+func (UnimplementedSplitServiceServer) mustEmbedUnimplementedSplitServiceServer() {}
+
+// UnsafeSplitServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SplitServiceServer will
+// result in compilation errors.
+type UnsafeSplitServiceServer interface {
+	mustEmbedUnimplementedSplitServiceServer()
+}
+
+func RegisterSplitServiceServer(s grpc.ServiceRegistrar, srv SplitServiceServer) {
+	s.RegisterService(&SplitService_ServiceDesc, srv)
+}
+
+func _SplitService_CreateSplitExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSplitExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SplitServiceServer).CreateSplitExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.SplitService/CreateSplitExpense",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SplitServiceServer).CreateSplitExpense(ctx, req.(*CreateSplitExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SplitService_ListSplitExpenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSplitExpensesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SplitServiceServer).ListSplitExpenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.SplitService/ListSplitExpenses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SplitServiceServer).ListSplitExpenses(ctx, req.(*ListSplitExpensesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SplitService_GetIous_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIousRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SplitServiceServer).GetIous(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.SplitService/GetIous",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SplitServiceServer).GetIous(ctx, req.(*GetIousRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SplitService_SimplifyDebts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimplifyDebtsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SplitServiceServer).SimplifyDebts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.SplitService/SimplifyDebts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SplitServiceServer).SimplifyDebts(ctx, req.(*SimplifyDebtsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SplitService_RecordSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordSettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SplitServiceServer).RecordSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.SplitService/RecordSettlement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SplitServiceServer).RecordSettlement(ctx, req.(*RecordSettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SplitService_ServiceDesc is the grpc.ServiceDesc for SplitService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SplitService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.SplitService",
+	HandlerType: (*SplitServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSplitExpense",
+			Handler:    _SplitService_CreateSplitExpense_Handler,
+		},
+		{
+			MethodName: "ListSplitExpenses",
+			Handler:    _SplitService_ListSplitExpenses_Handler,
+		},
+		{
+			MethodName: "GetIous",
+			Handler:    _SplitService_GetIous_Handler,
+		},
+		{
+			MethodName: "SimplifyDebts",
+			Handler:    _SplitService_SimplifyDebts_Handler,
+		},
+		{
+			MethodName: "RecordSettlement",
+			Handler:    _SplitService_RecordSettlement_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "expense_split.proto",
+}
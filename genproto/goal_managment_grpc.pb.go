@@ -27,6 +27,7 @@ type GoalServiceClient interface {
 	GetGoalById(ctx context.Context, in *GetGoalByIdRequest, opts ...grpc.CallOption) (*GoalResponse, error)
 	UpdateGoal(ctx context.Context, in *UpdateGoalRequest, opts ...grpc.CallOption) (*Responsee, error)
 	DeleteGoal(ctx context.Context, in *DeleteGoalRequest, opts ...grpc.CallOption) (*GoalDeleteResponse, error)
+	RestoreGoal(ctx context.Context, in *RestoreGoalRequest, opts ...grpc.CallOption) (*Responsee, error)
 }
 
 type goalServiceClient struct {
@@ -82,6 +83,15 @@ func (c *goalServiceClient) DeleteGoal(ctx context.Context, in *DeleteGoalReques
 	return out, nil
 }
 
+func (c *goalServiceClient) RestoreGoal(ctx context.Context, in *RestoreGoalRequest, opts ...grpc.CallOption) (*Responsee, error) {
+	out := new(Responsee)
+	err := c.cc.Invoke(ctx, "/budget.GoalService/RestoreGoal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoalServiceServer is the server API for GoalService service.
 // All implementations must embed UnimplementedGoalServiceServer
 // for forward compatibility
@@ -91,6 +101,7 @@ type GoalServiceServer interface {
 	GetGoalById(context.Context, *GetGoalByIdRequest) (*GoalResponse, error)
 	UpdateGoal(context.Context, *UpdateGoalRequest) (*Responsee, error)
 	DeleteGoal(context.Context, *DeleteGoalRequest) (*GoalDeleteResponse, error)
+	RestoreGoal(context.Context, *RestoreGoalRequest) (*Responsee, error)
 	mustEmbedUnimplementedGoalServiceServer()
 }
 
@@ -113,6 +124,9 @@ func (UnimplementedGoalServiceServer) UpdateGoal(context.Context, *UpdateGoalReq
 func (UnimplementedGoalServiceServer) DeleteGoal(context.Context, *DeleteGoalRequest) (*GoalDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGoal not implemented")
 }
+func (UnimplementedGoalServiceServer) RestoreGoal(context.Context, *RestoreGoalRequest) (*Responsee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreGoal not implemented")
+}
 func (UnimplementedGoalServiceServer) mustEmbedUnimplementedGoalServiceServer() {}
 
 // UnsafeGoalServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +230,24 @@ func _GoalService_DeleteGoal_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoalService_RestoreGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoalServiceServer).RestoreGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.GoalService/RestoreGoal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoalServiceServer).RestoreGoal(ctx, req.(*RestoreGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GoalService_ServiceDesc is the grpc.ServiceDesc for GoalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +275,10 @@ var GoalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGoal",
 			Handler:    _GoalService_DeleteGoal_Handler,
 		},
+		{
+			MethodName: "RestoreGoal",
+			Handler:    _GoalService_RestoreGoal_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "goal_managment.proto",
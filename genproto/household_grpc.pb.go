@@ -0,0 +1,393 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: household.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HouseholdServiceClient is the client API for HouseholdService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HouseholdServiceClient interface {
+	CreateHousehold(ctx context.Context, in *CreateHouseholdRequest, opts ...grpc.CallOption) (*Household, error)
+	GetHouseholdById(ctx context.Context, in *GetHouseholdByIdRequest, opts ...grpc.CallOption) (*Household, error)
+	ListHouseholds(ctx context.Context, in *ListHouseholdsRequest, opts ...grpc.CallOption) (*ListHouseholdsResponse, error)
+	UpdateHousehold(ctx context.Context, in *UpdateHouseholdRequest, opts ...grpc.CallOption) (*Household, error)
+	DeleteHousehold(ctx context.Context, in *DeleteHouseholdRequest, opts ...grpc.CallOption) (*HouseholdMessage, error)
+	SetHouseholdMember(ctx context.Context, in *SetHouseholdMemberRequest, opts ...grpc.CallOption) (*Household, error)
+	RemoveHouseholdMember(ctx context.Context, in *RemoveHouseholdMemberRequest, opts ...grpc.CallOption) (*Household, error)
+	ShareAccount(ctx context.Context, in *ShareAccountRequest, opts ...grpc.CallOption) (*HouseholdMessage, error)
+	ShareBudget(ctx context.Context, in *ShareBudgetRequest, opts ...grpc.CallOption) (*HouseholdMessage, error)
+}
+
+type householdServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHouseholdServiceClient(cc grpc.ClientConnInterface) HouseholdServiceClient {
+	return &householdServiceClient{cc}
+}
+
+func (c *householdServiceClient) CreateHousehold(ctx context.Context, in *CreateHouseholdRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/CreateHousehold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) GetHouseholdById(ctx context.Context, in *GetHouseholdByIdRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/GetHouseholdById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) ListHouseholds(ctx context.Context, in *ListHouseholdsRequest, opts ...grpc.CallOption) (*ListHouseholdsResponse, error) {
+	out := new(ListHouseholdsResponse)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/ListHouseholds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) UpdateHousehold(ctx context.Context, in *UpdateHouseholdRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/UpdateHousehold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) DeleteHousehold(ctx context.Context, in *DeleteHouseholdRequest, opts ...grpc.CallOption) (*HouseholdMessage, error) {
+	out := new(HouseholdMessage)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/DeleteHousehold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) SetHouseholdMember(ctx context.Context, in *SetHouseholdMemberRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/SetHouseholdMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) RemoveHouseholdMember(ctx context.Context, in *RemoveHouseholdMemberRequest, opts ...grpc.CallOption) (*Household, error) {
+	out := new(Household)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/RemoveHouseholdMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) ShareAccount(ctx context.Context, in *ShareAccountRequest, opts ...grpc.CallOption) (*HouseholdMessage, error) {
+	out := new(HouseholdMessage)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/ShareAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *householdServiceClient) ShareBudget(ctx context.Context, in *ShareBudgetRequest, opts ...grpc.CallOption) (*HouseholdMessage, error) {
+	out := new(HouseholdMessage)
+	err := c.cc.Invoke(ctx, "/budget.HouseholdService/ShareBudget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HouseholdServiceServer is the server API for HouseholdService service.
+// All implementations must embed UnimplementedHouseholdServiceServer
+// for forward compatibility
+type HouseholdServiceServer interface {
+	CreateHousehold(context.Context, *CreateHouseholdRequest) (*Household, error)
+	GetHouseholdById(context.Context, *GetHouseholdByIdRequest) (*Household, error)
+	ListHouseholds(context.Context, *ListHouseholdsRequest) (*ListHouseholdsResponse, error)
+	UpdateHousehold(context.Context, *UpdateHouseholdRequest) (*Household, error)
+	DeleteHousehold(context.Context, *DeleteHouseholdRequest) (*HouseholdMessage, error)
+	SetHouseholdMember(context.Context, *SetHouseholdMemberRequest) (*Household, error)
+	RemoveHouseholdMember(context.Context, *RemoveHouseholdMemberRequest) (*Household, error)
+	ShareAccount(context.Context, *ShareAccountRequest) (*HouseholdMessage, error)
+	ShareBudget(context.Context, *ShareBudgetRequest) (*HouseholdMessage, error)
+	mustEmbedUnimplementedHouseholdServiceServer()
+}
+
+// UnimplementedHouseholdServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHouseholdServiceServer struct {
+}
+
+func (UnimplementedHouseholdServiceServer) CreateHousehold(context.Context, *CreateHouseholdRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHousehold not implemented")
+}
+func (UnimplementedHouseholdServiceServer) GetHouseholdById(context.Context, *GetHouseholdByIdRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHouseholdById not implemented")
+}
+func (UnimplementedHouseholdServiceServer) ListHouseholds(context.Context, *ListHouseholdsRequest) (*ListHouseholdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHouseholds not implemented")
+}
+func (UnimplementedHouseholdServiceServer) UpdateHousehold(context.Context, *UpdateHouseholdRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateHousehold not implemented")
+}
+func (UnimplementedHouseholdServiceServer) DeleteHousehold(context.Context, *DeleteHouseholdRequest) (*HouseholdMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHousehold not implemented")
+}
+func (UnimplementedHouseholdServiceServer) SetHouseholdMember(context.Context, *SetHouseholdMemberRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHouseholdMember not implemented")
+}
+func (UnimplementedHouseholdServiceServer) RemoveHouseholdMember(context.Context, *RemoveHouseholdMemberRequest) (*Household, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveHouseholdMember not implemented")
+}
+func (UnimplementedHouseholdServiceServer) ShareAccount(context.Context, *ShareAccountRequest) (*HouseholdMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareAccount not implemented")
+}
+func (UnimplementedHouseholdServiceServer) ShareBudget(context.Context, *ShareBudgetRequest) (*HouseholdMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareBudget not implemented")
+}
+func (UnimplementedHouseholdServiceServer) mustEmbedUnimplementedHouseholdServiceServer() {}
+
+// UnsafeHouseholdServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HouseholdServiceServer will
+// result in compilation errors.
+type UnsafeHouseholdServiceServer interface {
+	mustEmbedUnimplementedHouseholdServiceServer()
+}
+
+func RegisterHouseholdServiceServer(s grpc.ServiceRegistrar, srv HouseholdServiceServer) {
+	s.RegisterService(&HouseholdService_ServiceDesc, srv)
+}
+
+func _HouseholdService_CreateHousehold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHouseholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).CreateHousehold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/CreateHousehold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).CreateHousehold(ctx, req.(*CreateHouseholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_GetHouseholdById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHouseholdByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).GetHouseholdById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/GetHouseholdById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).GetHouseholdById(ctx, req.(*GetHouseholdByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_ListHouseholds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHouseholdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).ListHouseholds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/ListHouseholds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).ListHouseholds(ctx, req.(*ListHouseholdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_UpdateHousehold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHouseholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).UpdateHousehold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/UpdateHousehold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).UpdateHousehold(ctx, req.(*UpdateHouseholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_DeleteHousehold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHouseholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).DeleteHousehold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/DeleteHousehold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).DeleteHousehold(ctx, req.(*DeleteHouseholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_SetHouseholdMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHouseholdMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).SetHouseholdMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/SetHouseholdMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).SetHouseholdMember(ctx, req.(*SetHouseholdMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_RemoveHouseholdMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveHouseholdMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).RemoveHouseholdMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/RemoveHouseholdMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).RemoveHouseholdMember(ctx, req.(*RemoveHouseholdMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_ShareAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).ShareAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/ShareAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).ShareAccount(ctx, req.(*ShareAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HouseholdService_ShareBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HouseholdServiceServer).ShareBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.HouseholdService/ShareBudget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HouseholdServiceServer).ShareBudget(ctx, req.(*ShareBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HouseholdService_ServiceDesc is the grpc.ServiceDesc for HouseholdService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HouseholdService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.HouseholdService",
+	HandlerType: (*HouseholdServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateHousehold",
+			Handler:    _HouseholdService_CreateHousehold_Handler,
+		},
+		{
+			MethodName: "GetHouseholdById",
+			Handler:    _HouseholdService_GetHouseholdById_Handler,
+		},
+		{
+			MethodName: "ListHouseholds",
+			Handler:    _HouseholdService_ListHouseholds_Handler,
+		},
+		{
+			MethodName: "UpdateHousehold",
+			Handler:    _HouseholdService_UpdateHousehold_Handler,
+		},
+		{
+			MethodName: "DeleteHousehold",
+			Handler:    _HouseholdService_DeleteHousehold_Handler,
+		},
+		{
+			MethodName: "SetHouseholdMember",
+			Handler:    _HouseholdService_SetHouseholdMember_Handler,
+		},
+		{
+			MethodName: "RemoveHouseholdMember",
+			Handler:    _HouseholdService_RemoveHouseholdMember_Handler,
+		},
+		{
+			MethodName: "ShareAccount",
+			Handler:    _HouseholdService_ShareAccount_Handler,
+		},
+		{
+			MethodName: "ShareBudget",
+			Handler:    _HouseholdService_ShareBudget_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "household.proto",
+}
@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: net_worth.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NetWorthSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId      string  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Date        string  `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Assets      float64 `protobuf:"fixed64,3,opt,name=assets,proto3" json:"assets,omitempty"`
+	Liabilities float64 `protobuf:"fixed64,4,opt,name=liabilities,proto3" json:"liabilities,omitempty"`
+	NetWorth    float64 `protobuf:"fixed64,5,opt,name=net_worth,json=netWorth,proto3" json:"net_worth,omitempty"`
+	Currency    string  `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *NetWorthSnapshot) Reset() {
+	*x = NetWorthSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_worth_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetWorthSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetWorthSnapshot) ProtoMessage() {}
+
+func (x *NetWorthSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_net_worth_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetWorthSnapshot.ProtoReflect.Descriptor instead.
+func (*NetWorthSnapshot) Descriptor() ([]byte, []int) {
+	return file_net_worth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NetWorthSnapshot) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NetWorthSnapshot) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *NetWorthSnapshot) GetAssets() float64 {
+	if x != nil {
+		return x.Assets
+	}
+	return 0
+}
+
+func (x *NetWorthSnapshot) GetLiabilities() float64 {
+	if x != nil {
+		return x.Liabilities
+	}
+	return 0
+}
+
+func (x *NetWorthSnapshot) GetNetWorth() float64 {
+	if x != nil {
+		return x.NetWorth
+	}
+	return 0
+}
+
+func (x *NetWorthSnapshot) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetNetWorthHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate string `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+}
+
+func (x *GetNetWorthHistoryRequest) Reset() {
+	*x = GetNetWorthHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_worth_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNetWorthHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNetWorthHistoryRequest) ProtoMessage() {}
+
+func (x *GetNetWorthHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_net_worth_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNetWorthHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetNetWorthHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_net_worth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetNetWorthHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetNetWorthHistoryRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *GetNetWorthHistoryRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+type NetWorthHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*NetWorthSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *NetWorthHistoryResponse) Reset() {
+	*x = NetWorthHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_worth_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetWorthHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetWorthHistoryResponse) ProtoMessage() {}
+
+func (x *NetWorthHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_net_worth_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetWorthHistoryResponse.ProtoReflect.Descriptor instead.
+func (*NetWorthHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_net_worth_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NetWorthHistoryResponse) GetSnapshots() []*NetWorthSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+var File_net_worth_proto protoreflect.FileDescriptor
+
+var file_net_worth_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x4e, 0x65,
+	0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x74, 0x5f, 0x77, 0x6f, 0x72,
+	0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x57, 0x6f, 0x72,
+	0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x6e,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x22, 0x51,
+	0x0a, 0x17, 0x4e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x2e, 0x4e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x32, 0x6b, 0x0a, 0x0f, 0x4e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x58, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x57, 0x6f,
+	0x72, 0x74, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x2e, 0x62, 0x75, 0x64,
+	0x67, 0x65, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x2e, 0x4e, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x74, 0x68, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0b,
+	0x5a, 0x09, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_net_worth_proto_rawDescOnce sync.Once
+	file_net_worth_proto_rawDescData = file_net_worth_proto_rawDesc
+)
+
+func file_net_worth_proto_rawDescGZIP() []byte {
+	file_net_worth_proto_rawDescOnce.Do(func() {
+		file_net_worth_proto_rawDescData = protoimpl.X.CompressGZIP(file_net_worth_proto_rawDescData)
+	})
+	return file_net_worth_proto_rawDescData
+}
+
+var file_net_worth_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_net_worth_proto_goTypes = []interface{}{
+	(*NetWorthSnapshot)(nil),          // 0: budget.NetWorthSnapshot
+	(*GetNetWorthHistoryRequest)(nil), // 1: budget.GetNetWorthHistoryRequest
+	(*NetWorthHistoryResponse)(nil),   // 2: budget.NetWorthHistoryResponse
+}
+var file_net_worth_proto_depIdxs = []int32{
+	0, // 0: budget.NetWorthHistoryResponse.snapshots:type_name -> budget.NetWorthSnapshot
+	1, // 1: budget.NetWorthService.GetNetWorthHistory:input_type -> budget.GetNetWorthHistoryRequest
+	2, // 2: budget.NetWorthService.GetNetWorthHistory:output_type -> budget.NetWorthHistoryResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_net_worth_proto_init() }
+func file_net_worth_proto_init() {
+	if File_net_worth_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_net_worth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetWorthSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_worth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNetWorthHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_worth_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetWorthHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_net_worth_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_net_worth_proto_goTypes,
+		DependencyIndexes: file_net_worth_proto_depIdxs,
+		MessageInfos:      file_net_worth_proto_msgTypes,
+	}.Build()
+	File_net_worth_proto = out.File
+	file_net_worth_proto_rawDesc = nil
+	file_net_worth_proto_goTypes = nil
+	file_net_worth_proto_depIdxs = nil
+}
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: net_worth.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NetWorthServiceClient is the client API for NetWorthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NetWorthServiceClient interface {
+	GetNetWorthHistory(ctx context.Context, in *GetNetWorthHistoryRequest, opts ...grpc.CallOption) (*NetWorthHistoryResponse, error)
+}
+
+type netWorthServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNetWorthServiceClient(cc grpc.ClientConnInterface) NetWorthServiceClient {
+	return &netWorthServiceClient{cc}
+}
+
+func (c *netWorthServiceClient) GetNetWorthHistory(ctx context.Context, in *GetNetWorthHistoryRequest, opts ...grpc.CallOption) (*NetWorthHistoryResponse, error) {
+	out := new(NetWorthHistoryResponse)
+	err := c.cc.Invoke(ctx, "/budget.NetWorthService/GetNetWorthHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NetWorthServiceServer is the server API for NetWorthService service.
+// All implementations must embed UnimplementedNetWorthServiceServer
+// for forward compatibility
+type NetWorthServiceServer interface {
+	GetNetWorthHistory(context.Context, *GetNetWorthHistoryRequest) (*NetWorthHistoryResponse, error)
+	mustEmbedUnimplementedNetWorthServiceServer()
+}
+
+// UnimplementedNetWorthServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNetWorthServiceServer struct {
+}
+
+func (UnimplementedNetWorthServiceServer) GetNetWorthHistory(context.Context, *GetNetWorthHistoryRequest) (*NetWorthHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetWorthHistory not implemented")
+}
+func (UnimplementedNetWorthServiceServer) mustEmbedUnimplementedNetWorthServiceServer() {}
+
+// UnsafeNetWorthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NetWorthServiceServer will
+// result in compilation errors.
+type UnsafeNetWorthServiceServer interface {
+	mustEmbedUnimplementedNetWorthServiceServer()
+}
+
+func RegisterNetWorthServiceServer(s grpc.ServiceRegistrar, srv NetWorthServiceServer) {
+	s.RegisterService(&NetWorthService_ServiceDesc, srv)
+}
+
+func _NetWorthService_GetNetWorthHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetWorthHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetWorthServiceServer).GetNetWorthHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.NetWorthService/GetNetWorthHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetWorthServiceServer).GetNetWorthHistory(ctx, req.(*GetNetWorthHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NetWorthService_ServiceDesc is the grpc.ServiceDesc for NetWorthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NetWorthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.NetWorthService",
+	HandlerType: (*NetWorthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNetWorthHistory",
+			Handler:    _NetWorthService_GetNetWorthHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "net_worth.proto",
+}
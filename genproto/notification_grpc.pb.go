@@ -25,6 +25,7 @@ type NotificationtServiceClient interface {
 	GetNotification(ctx context.Context, in *GetNotificationByidRequest, opts ...grpc.CallOption) (*GetNotificationByidResponse, error)
 	DeleteNotification(ctx context.Context, in *GetNotificationByidRequest, opts ...grpc.CallOption) (*NotificationsResponse, error)
 	ListNotification(ctx context.Context, in *Void, opts ...grpc.CallOption) (*ListNotificationResponse, error)
+	RestoreNotification(ctx context.Context, in *RestoreNotificationRequest, opts ...grpc.CallOption) (*NotificationsResponse, error)
 }
 
 type notificationtServiceClient struct {
@@ -62,6 +63,15 @@ func (c *notificationtServiceClient) ListNotification(ctx context.Context, in *V
 	return out, nil
 }
 
+func (c *notificationtServiceClient) RestoreNotification(ctx context.Context, in *RestoreNotificationRequest, opts ...grpc.CallOption) (*NotificationsResponse, error) {
+	out := new(NotificationsResponse)
+	err := c.cc.Invoke(ctx, "/notifications.NotificationtService/RestoreNotification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationtServiceServer is the server API for NotificationtService service.
 // All implementations must embed UnimplementedNotificationtServiceServer
 // for forward compatibility
@@ -69,6 +79,7 @@ type NotificationtServiceServer interface {
 	GetNotification(context.Context, *GetNotificationByidRequest) (*GetNotificationByidResponse, error)
 	DeleteNotification(context.Context, *GetNotificationByidRequest) (*NotificationsResponse, error)
 	ListNotification(context.Context, *Void) (*ListNotificationResponse, error)
+	RestoreNotification(context.Context, *RestoreNotificationRequest) (*NotificationsResponse, error)
 	mustEmbedUnimplementedNotificationtServiceServer()
 }
 
@@ -85,6 +96,9 @@ func (UnimplementedNotificationtServiceServer) DeleteNotification(context.Contex
 func (UnimplementedNotificationtServiceServer) ListNotification(context.Context, *Void) (*ListNotificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListNotification not implemented")
 }
+func (UnimplementedNotificationtServiceServer) RestoreNotification(context.Context, *RestoreNotificationRequest) (*NotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreNotification not implemented")
+}
 func (UnimplementedNotificationtServiceServer) mustEmbedUnimplementedNotificationtServiceServer() {}
 
 // UnsafeNotificationtServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +166,24 @@ func _NotificationtService_ListNotification_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationtService_RestoreNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationtServiceServer).RestoreNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/notifications.NotificationtService/RestoreNotification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationtServiceServer).RestoreNotification(ctx, req.(*RestoreNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationtService_ServiceDesc is the grpc.ServiceDesc for NotificationtService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +203,10 @@ var NotificationtService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListNotification",
 			Handler:    _NotificationtService_ListNotification_Handler,
 		},
+		{
+			MethodName: "RestoreNotification",
+			Handler:    _NotificationtService_RestoreNotification_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification.proto",
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: reconciliation.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReconciliationServiceClient is the client API for ReconciliationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReconciliationServiceClient interface {
+	StartReconciliation(ctx context.Context, in *StartReconciliationRequest, opts ...grpc.CallOption) (*ReconciliationStatusResponse, error)
+	MarkTransactionsCleared(ctx context.Context, in *MarkTransactionsClearedRequest, opts ...grpc.CallOption) (*MarkTransactionsClearedResponse, error)
+	FinishReconciliation(ctx context.Context, in *FinishReconciliationRequest, opts ...grpc.CallOption) (*Reconciliation, error)
+	ListReconciliations(ctx context.Context, in *ListReconciliationsRequest, opts ...grpc.CallOption) (*ListReconciliationsResponse, error)
+}
+
+type reconciliationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReconciliationServiceClient(cc grpc.ClientConnInterface) ReconciliationServiceClient {
+	return &reconciliationServiceClient{cc}
+}
+
+func (c *reconciliationServiceClient) StartReconciliation(ctx context.Context, in *StartReconciliationRequest, opts ...grpc.CallOption) (*ReconciliationStatusResponse, error) {
+	out := new(ReconciliationStatusResponse)
+	err := c.cc.Invoke(ctx, "/budget.ReconciliationService/StartReconciliation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconciliationServiceClient) MarkTransactionsCleared(ctx context.Context, in *MarkTransactionsClearedRequest, opts ...grpc.CallOption) (*MarkTransactionsClearedResponse, error) {
+	out := new(MarkTransactionsClearedResponse)
+	err := c.cc.Invoke(ctx, "/budget.ReconciliationService/MarkTransactionsCleared", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconciliationServiceClient) FinishReconciliation(ctx context.Context, in *FinishReconciliationRequest, opts ...grpc.CallOption) (*Reconciliation, error) {
+	out := new(Reconciliation)
+	err := c.cc.Invoke(ctx, "/budget.ReconciliationService/FinishReconciliation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reconciliationServiceClient) ListReconciliations(ctx context.Context, in *ListReconciliationsRequest, opts ...grpc.CallOption) (*ListReconciliationsResponse, error) {
+	out := new(ListReconciliationsResponse)
+	err := c.cc.Invoke(ctx, "/budget.ReconciliationService/ListReconciliations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReconciliationServiceServer is the server API for ReconciliationService service.
+// All implementations must embed UnimplementedReconciliationServiceServer
+// for forward compatibility
+type ReconciliationServiceServer interface {
+	StartReconciliation(context.Context, *StartReconciliationRequest) (*ReconciliationStatusResponse, error)
+	MarkTransactionsCleared(context.Context, *MarkTransactionsClearedRequest) (*MarkTransactionsClearedResponse, error)
+	FinishReconciliation(context.Context, *FinishReconciliationRequest) (*Reconciliation, error)
+	ListReconciliations(context.Context, *ListReconciliationsRequest) (*ListReconciliationsResponse, error)
+	mustEmbedUnimplementedReconciliationServiceServer()
+}
+
+// UnimplementedReconciliationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReconciliationServiceServer struct {
+}
+
+func (UnimplementedReconciliationServiceServer) StartReconciliation(context.Context, *StartReconciliationRequest) (*ReconciliationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartReconciliation not implemented")
+}
+func (UnimplementedReconciliationServiceServer) MarkTransactionsCleared(context.Context, *MarkTransactionsClearedRequest) (*MarkTransactionsClearedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkTransactionsCleared not implemented")
+}
+func (UnimplementedReconciliationServiceServer) FinishReconciliation(context.Context, *FinishReconciliationRequest) (*Reconciliation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishReconciliation not implemented")
+}
+func (UnimplementedReconciliationServiceServer) ListReconciliations(context.Context, *ListReconciliationsRequest) (*ListReconciliationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReconciliations not implemented")
+}
+func (UnimplementedReconciliationServiceServer) mustEmbedUnimplementedReconciliationServiceServer() {}
+
+// UnsafeReconciliationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReconciliationServiceServer will
+// result in compilation errors.
+type UnsafeReconciliationServiceServer interface {
+	mustEmbedUnimplementedReconciliationServiceServer()
+}
+
+func RegisterReconciliationServiceServer(s grpc.ServiceRegistrar, srv ReconciliationServiceServer) {
+	s.RegisterService(&ReconciliationService_ServiceDesc, srv)
+}
+
+func _ReconciliationService_StartReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServiceServer).StartReconciliation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ReconciliationService/StartReconciliation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServiceServer).StartReconciliation(ctx, req.(*StartReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReconciliationService_MarkTransactionsCleared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkTransactionsClearedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServiceServer).MarkTransactionsCleared(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ReconciliationService/MarkTransactionsCleared",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServiceServer).MarkTransactionsCleared(ctx, req.(*MarkTransactionsClearedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReconciliationService_FinishReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServiceServer).FinishReconciliation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ReconciliationService/FinishReconciliation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServiceServer).FinishReconciliation(ctx, req.(*FinishReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReconciliationService_ListReconciliations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReconciliationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReconciliationServiceServer).ListReconciliations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.ReconciliationService/ListReconciliations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReconciliationServiceServer).ListReconciliations(ctx, req.(*ListReconciliationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReconciliationService_ServiceDesc is the grpc.ServiceDesc for ReconciliationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReconciliationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.ReconciliationService",
+	HandlerType: (*ReconciliationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartReconciliation",
+			Handler:    _ReconciliationService_StartReconciliation_Handler,
+		},
+		{
+			MethodName: "MarkTransactionsCleared",
+			Handler:    _ReconciliationService_MarkTransactionsCleared_Handler,
+		},
+		{
+			MethodName: "FinishReconciliation",
+			Handler:    _ReconciliationService_FinishReconciliation_Handler,
+		},
+		{
+			MethodName: "ListReconciliations",
+			Handler:    _ReconciliationService_ListReconciliations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reconciliation.proto",
+}
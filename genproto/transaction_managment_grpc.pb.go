@@ -27,6 +27,8 @@ type TransactionServiceClient interface {
 	GetTransactionById(ctx context.Context, in *GetTransactionByIdRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	UpdateTransaction(ctx context.Context, in *UpdateTransactionRequest, opts ...grpc.CallOption) (*Response, error)
 	DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*TransactionDeleteResponse, error)
+	RestoreTransaction(ctx context.Context, in *RestoreTransactionRequest, opts ...grpc.CallOption) (*Response, error)
+	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
 }
 
 type transactionServiceClient struct {
@@ -82,6 +84,24 @@ func (c *transactionServiceClient) DeleteTransaction(ctx context.Context, in *De
 	return out, nil
 }
 
+func (c *transactionServiceClient) RestoreTransaction(ctx context.Context, in *RestoreTransactionRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/budget.TransactionService/RestoreTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionServiceClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/budget.TransactionService/CreateTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility
@@ -91,6 +111,8 @@ type TransactionServiceServer interface {
 	GetTransactionById(context.Context, *GetTransactionByIdRequest) (*TransactionResponse, error)
 	UpdateTransaction(context.Context, *UpdateTransactionRequest) (*Response, error)
 	DeleteTransaction(context.Context, *DeleteTransactionRequest) (*TransactionDeleteResponse, error)
+	RestoreTransaction(context.Context, *RestoreTransactionRequest) (*Response, error)
+	CreateTransfer(context.Context, *CreateTransferRequest) (*TransferResponse, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -113,6 +135,12 @@ func (UnimplementedTransactionServiceServer) UpdateTransaction(context.Context,
 func (UnimplementedTransactionServiceServer) DeleteTransaction(context.Context, *DeleteTransactionRequest) (*TransactionDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTransaction not implemented")
 }
+func (UnimplementedTransactionServiceServer) RestoreTransaction(context.Context, *RestoreTransactionRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTransaction not implemented")
+}
+func (UnimplementedTransactionServiceServer) CreateTransfer(context.Context, *CreateTransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfer not implemented")
+}
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 
 // UnsafeTransactionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +244,42 @@ func _TransactionService_DeleteTransaction_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_RestoreTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).RestoreTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.TransactionService/RestoreTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).RestoreTransaction(ctx, req.(*RestoreTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionService_CreateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).CreateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.TransactionService/CreateTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +307,14 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTransaction",
 			Handler:    _TransactionService_DeleteTransaction_Handler,
 		},
+		{
+			MethodName: "RestoreTransaction",
+			Handler:    _TransactionService_RestoreTransaction_Handler,
+		},
+		{
+			MethodName: "CreateTransfer",
+			Handler:    _TransactionService_CreateTransfer_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transaction_managment.proto",
@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: user_settings.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// UserSettingsServiceClient is the client API for UserSettingsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserSettingsServiceClient interface {
+	GetUserSettings(ctx context.Context, in *GetUserSettingsRequest, opts ...grpc.CallOption) (*UserSettings, error)
+	UpdateUserSettings(ctx context.Context, in *UpdateUserSettingsRequest, opts ...grpc.CallOption) (*UserSettings, error)
+}
+
+type userSettingsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserSettingsServiceClient(cc grpc.ClientConnInterface) UserSettingsServiceClient {
+	return &userSettingsServiceClient{cc}
+}
+
+func (c *userSettingsServiceClient) GetUserSettings(ctx context.Context, in *GetUserSettingsRequest, opts ...grpc.CallOption) (*UserSettings, error) {
+	out := new(UserSettings)
+	err := c.cc.Invoke(ctx, "/budget.UserSettingsService/GetUserSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userSettingsServiceClient) UpdateUserSettings(ctx context.Context, in *UpdateUserSettingsRequest, opts ...grpc.CallOption) (*UserSettings, error) {
+	out := new(UserSettings)
+	err := c.cc.Invoke(ctx, "/budget.UserSettingsService/UpdateUserSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserSettingsServiceServer is the server API for UserSettingsService service.
+// All implementations must embed UnimplementedUserSettingsServiceServer
+// for forward compatibility
+type UserSettingsServiceServer interface {
+	GetUserSettings(context.Context, *GetUserSettingsRequest) (*UserSettings, error)
+	UpdateUserSettings(context.Context, *UpdateUserSettingsRequest) (*UserSettings, error)
+	mustEmbedUnimplementedUserSettingsServiceServer()
+}
+
+// UnimplementedUserSettingsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserSettingsServiceServer struct {
+}
+
+func (UnimplementedUserSettingsServiceServer) GetUserSettings(context.Context, *GetUserSettingsRequest) (*UserSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserSettings not implemented")
+}
+func (UnimplementedUserSettingsServiceServer) UpdateUserSettings(context.Context, *UpdateUserSettingsRequest) (*UserSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserSettings not implemented")
+}
+func (UnimplementedUserSettingsServiceServer) mustEmbedUnimplementedUserSettingsServiceServer() {}
+
+// UnsafeUserSettingsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserSettingsServiceServer will
+// result in compilation errors.
+type UnsafeUserSettingsServiceServer interface {
+	mustEmbedUnimplementedUserSettingsServiceServer()
+}
+
+func RegisterUserSettingsServiceServer(s grpc.ServiceRegistrar, srv UserSettingsServiceServer) {
+	s.RegisterService(&UserSettingsService_ServiceDesc, srv)
+}
+
+func _UserSettingsService_GetUserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserSettingsServiceServer).GetUserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.UserSettingsService/GetUserSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserSettingsServiceServer).GetUserSettings(ctx, req.(*GetUserSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserSettingsService_UpdateUserSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserSettingsServiceServer).UpdateUserSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/budget.UserSettingsService/UpdateUserSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserSettingsServiceServer).UpdateUserSettings(ctx, req.(*UpdateUserSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserSettingsService_ServiceDesc is the grpc.ServiceDesc for UserSettingsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserSettingsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "budget.UserSettingsService",
+	HandlerType: (*UserSettingsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserSettings",
+			Handler:    _UserSettingsService_GetUserSettings_Handler,
+		},
+		{
+			MethodName: "UpdateUserSettings",
+			Handler:    _UserSettingsService_UpdateUserSettings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "user_settings.proto",
+}
@@ -0,0 +1,140 @@
+package health
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// checkTimeout bounds a single run of one check
+const checkTimeout = 3 * time.Second
+
+// Checker serves grpc.health.v1 for the server and each of its dependencies. Every
+// dependency is reported under its own service name, e.g. "mongo"; the server as a
+// whole, the empty service name, is serving while all critical dependencies are.
+type Checker struct {
+	server   *grpchealth.Server
+	interval time.Duration
+
+	mu     sync.Mutex
+	checks []check
+	last   map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+type check struct {
+	service  string
+	critical bool
+	run      func(ctx context.Context) error
+}
+
+// NewChecker returns a Checker that runs its checks every interval. The server
+// reports not serving until the first checks have passed.
+func NewChecker(interval time.Duration) *Checker {
+	c := &Checker{
+		server:   grpchealth.NewServer(),
+		interval: interval,
+		last:     map[string]healthpb.HealthCheckResponse_ServingStatus{},
+	}
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Add reports service as serving while run succeeds. A failing critical check
+// also takes the whole server out of service.
+func (c *Checker) Add(service string, critical bool, run func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{service, critical, run})
+	c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Register adds the health service to s
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.server)
+}
+
+// Run checks right away and then every interval until ctx is cancelled
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll runs every check once, concurrently, and updates the statuses
+func (c *Checker) CheckAll(ctx context.Context) {
+	c.mu.Lock()
+	checks := c.checks
+	c.mu.Unlock()
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			errs[i] = ch.run(checkCtx)
+		}()
+	}
+	wg.Wait()
+
+	// A check cut short by shutdown says nothing about the dependency
+	if ctx.Err() != nil {
+		return
+	}
+
+	overall := healthpb.HealthCheckResponse_SERVING
+	for i, ch := range checks {
+		status := healthpb.HealthCheckResponse_SERVING
+		if errs[i] != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			if ch.critical {
+				overall = status
+			}
+		}
+		c.set(ch.service, status, errs[i])
+	}
+	c.set("", overall, nil)
+}
+
+// set updates the status of service, logging when it changes
+func (c *Checker) set(service string, status healthpb.HealthCheckResponse_ServingStatus, err error) {
+	c.mu.Lock()
+	changed := c.last[service] != status
+	c.last[service] = status
+	c.mu.Unlock()
+
+	if changed {
+		name := service
+		if name == "" {
+			name = "server"
+		}
+		if err != nil {
+			log.Printf("health: %s is %v: %v", name, status, err)
+		} else {
+			log.Printf("health: %s is %v", name, status)
+		}
+	}
+	c.server.SetServingStatus(service, status)
+}
+
+// Shutdown reports everything as not serving from now on, so probes take the
+// server out of rotation before it stops accepting calls
+func (c *Checker) Shutdown(ctx context.Context) error {
+	c.server.Shutdown()
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"sync"
 
+	"budget-service/metrics"
+
 	"github.com/segmentio/kafka-go"
 )
 
@@ -22,6 +24,11 @@ type KafkaConsumerManager struct {
 	consumers map[string]*kafka.Reader
 	handlers  map[string]func(message []byte)
 	mu        sync.Mutex
+
+	// ctx is cancelled by Close, which then waits for running consumers
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 func NewKafkaProducer(brokers []string) (KafkaProducer, error) {
@@ -33,10 +40,16 @@ func NewKafkaProducer(brokers []string) (KafkaProducer, error) {
 }
 
 func (p *Producer) ProduceMessages(topic string, message []byte) error {
-	return p.writer.WriteMessages(context.Background(), kafka.Message{
+	err := p.writer.WriteMessages(context.Background(), kafka.Message{
 		Topic: topic,
 		Value: message,
 	})
+	if err != nil {
+		metrics.KafkaProduceFailures.WithLabelValues(topic).Inc()
+		return err
+	}
+	metrics.KafkaProduced.WithLabelValues(topic).Inc()
+	return nil
 }
 
 func (p *Producer) Close() error {
@@ -44,9 +57,12 @@ func (p *Producer) Close() error {
 }
 
 func NewKafkaConsumerManager() *KafkaConsumerManager {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &KafkaConsumerManager{
 		consumers: make(map[string]*kafka.Reader),
 		handlers:  make(map[string]func(message []byte)),
+		ctx:       ctx,
+		cancel:    cancel,
 	}
 }
 
@@ -68,33 +84,49 @@ func (kcm *KafkaConsumerManager) RegisterConsumer(brokers []string, topic, group
 	kcm.consumers[topic] = reader
 	kcm.handlers[topic] = handler
 
+	kcm.wg.Add(1)
 	go kcm.consumeMessages(topic)
 
 	return nil
 }
 
 func (kcm *KafkaConsumerManager) consumeMessages(topic string) {
+	defer kcm.wg.Done()
+
+	kcm.mu.Lock()
 	reader := kcm.consumers[topic]
 	handler := kcm.handlers[topic]
+	kcm.mu.Unlock()
 
 	for {
-		msg, err := reader.ReadMessage(context.Background())
+		msg, err := reader.ReadMessage(kcm.ctx)
 		if err != nil {
+			if kcm.ctx.Err() != nil {
+				return
+			}
 			log.Printf("Error reading message from topic %s: %v", topic, err)
+			metrics.KafkaConsumeFailures.WithLabelValues(topic).Inc()
 			continue
 		}
+		metrics.KafkaConsumed.WithLabelValues(topic).Inc()
 		handler(msg.Value)
 	}
 }
 
+// Close stops the consumers, letting each finish the message it is handling,
+// and then closes their readers
 func (kcm *KafkaConsumerManager) Close() error {
+	kcm.cancel()
+	kcm.wg.Wait()
+
 	kcm.mu.Lock()
 	defer kcm.mu.Unlock()
 
+	var errs []error
 	for _, reader := range kcm.consumers {
 		if err := reader.Close(); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// CheckBrokers succeeds when at least one of the brokers accepts a connection
+func CheckBrokers(ctx context.Context, brokers []string) error {
+	var errs []error
+	for _, broker := range brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conn.Close()
+		return nil
+	}
+	return fmt.Errorf("no kafka broker reachable: %w", errors.Join(errs...))
+}
+
+// CheckLag fails when a consumer has fallen more than maxLag messages behind
+func (kcm *KafkaConsumerManager) CheckLag(maxLag int64) error {
+	kcm.mu.Lock()
+	defer kcm.mu.Unlock()
+
+	var errs []error
+	for topic, reader := range kcm.consumers {
+		// Reader.Lag doesn't work with consumer groups, the lag gauge in the stats does
+		if lag := reader.Stats().Lag; lag > maxLag {
+			errs = append(errs, fmt.Errorf("consumer of topic %s is %d messages behind", topic, lag))
+		}
+	}
+	return errors.Join(errs...)
+}
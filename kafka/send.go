@@ -6,15 +6,15 @@ import (
 	"budget-service/model"
 )
 
-func CreateNotification(kaf KafkaProducer, request *model.Send) error {
+func CreateNotification(kaf KafkaProducer, topic string, request *model.Send) error {
 	response, err := json.Marshal(request)
 	if err != nil {
 		log.Println("cannot produce messages via kafka", err.Error())
 		return err
 	}
-	err = kaf.ProduceMessages("create", response)
+	err = kaf.ProduceMessages(topic, response)
 	if err != nil {
-		log.Fatal("Error while ProduceMessages: ", err.Error())
+		log.Println("Error while ProduceMessages: ", err.Error())
 		return err
 	}
 	return nil
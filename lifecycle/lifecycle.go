@@ -0,0 +1,99 @@
+package lifecycle
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Manager runs the service's background jobs and, on SIGINT or SIGTERM, stops
+// everything registered with it in the reverse order of registration, so what
+// was started last, like the gRPC server, stops first and the database last.
+type Manager struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+
+	mu    sync.Mutex
+	steps []step
+}
+
+type step struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// New returns a Manager that gives the whole shutdown timeout to finish
+func New(timeout time.Duration) *Manager {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	return &Manager{ctx: ctx, cancel: cancel, timeout: timeout}
+}
+
+// OnStop registers stop to be called on shutdown. It should return once name has
+// stopped or ctx is done.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps = append(m.steps, step{name, stop})
+}
+
+// Go runs job in the background until shutdown reaches it, then cancels its
+// context and waits for it to return
+func (m *Manager) Go(name string, job func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		job(ctx)
+	}()
+
+	m.OnStop(name, func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	})
+}
+
+// Shutdown starts the shutdown without a signal, e.g. when the server fails
+func (m *Manager) Shutdown() {
+	m.cancel()
+}
+
+// Wait blocks until a signal or Shutdown, then stops everything registered.
+// Steps still running at the deadline are abandoned so the process can exit.
+func (m *Manager) Wait() {
+	<-m.ctx.Done()
+	m.cancel()
+	log.Printf("shutting down, waiting up to %v", m.timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	steps := m.steps
+	m.mu.Unlock()
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		errc := make(chan error, 1)
+		go func() { errc <- s.stop(ctx) }()
+
+		select {
+		case err := <-errc:
+			if err != nil {
+				log.Printf("Failed to stop %s: %v", s.name, err)
+				continue
+			}
+			log.Printf("stopped %s", s.name)
+		case <-ctx.Done():
+			log.Printf("Gave up waiting for %s to stop: %v", s.name, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric of the service
+const namespace = "budget"
+
+var (
+	// RPCDuration times gRPC calls by full method name and status code
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to handle gRPC calls, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// MongoDuration times MongoDB commands by collection and command name
+	MongoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "operation_duration_seconds",
+		Help:      "Time taken by MongoDB commands, by collection and command.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"collection", "operation"})
+
+	// MongoErrors counts failed MongoDB commands by collection and command name
+	MongoErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "operation_errors_total",
+		Help:      "MongoDB commands that failed, by collection and command.",
+	}, []string{"collection", "operation"})
+
+	// KafkaProduced and KafkaProduceFailures count messages written to each topic
+	KafkaProduced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "kafka",
+		Name:      "messages_produced_total",
+		Help:      "Messages written to Kafka, by topic.",
+	}, []string{"topic"})
+	KafkaProduceFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "kafka",
+		Name:      "produce_failures_total",
+		Help:      "Messages that couldn't be written to Kafka, by topic.",
+	}, []string{"topic"})
+
+	// KafkaConsumed and KafkaConsumeFailures count messages read from each topic
+	KafkaConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "kafka",
+		Name:      "messages_consumed_total",
+		Help:      "Messages read from Kafka, by topic.",
+	}, []string{"topic"})
+	KafkaConsumeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "kafka",
+		Name:      "consume_failures_total",
+		Help:      "Failed reads from Kafka, by topic.",
+	}, []string{"topic"})
+
+	// BudgetsActive and BudgetsOverLimit count budgets whose period includes today
+	BudgetsActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "budgets_active",
+		Help:      "Budgets whose period includes today.",
+	})
+	BudgetsOverLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "budgets_over_limit",
+		Help:      "Active budgets with more spent than budgeted.",
+	})
+)
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"strings"
+
+	"budget-service/appctx"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	authorizationHeader = "authorization"
+	roleClaim           = "role"
+)
+
+// publicServices answer without a token, so probes can reach them
+var publicServices = map[string]bool{
+	"grpc.health.v1.Health": true,
+}
+
+// isPublic reports whether method, e.g. /grpc.health.v1.Health/Check, belongs to a public service
+func isPublic(method string) bool {
+	service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return publicServices[service]
+}
+
+// Authenticator checks the bearer token of every call. Tokens are JWTs signed either
+// with HS256 and the shared secret or with RS256 and the private key whose public
+// half is configured.
+type Authenticator struct {
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+// NewAuthenticator accepts HS256 tokens when secret is set and RS256 tokens when
+// publicKeyPEM is. At least one of them is required.
+func NewAuthenticator(secret string, publicKeyPEM []byte) (*Authenticator, error) {
+	a := &Authenticator{secret: []byte(secret)}
+	if len(publicKeyPEM) > 0 {
+		key, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		a.publicKey = key
+	}
+	if len(a.secret) == 0 && a.publicKey == nil {
+		return nil, errors.New("auth: a token secret or an RSA public key is required")
+	}
+	return a, nil
+}
+
+// Unary authenticates the caller and scopes the call to the data its role may touch.
+// Users are limited to their own data and requests naming another user_id are
+// rejected; support and admin staff act on the user the request names, or on
+// every user's data when it names none.
+func (a *Authenticator) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		c, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkUser(req); err != nil {
+			return nil, err
+		}
+		return handler(c.scope(ctx, userIDField(req)), req)
+	}
+}
+
+// Stream does the same for streaming calls, checking every message the client sends.
+// Staff streams aren't limited to one user.
+func (a *Authenticator) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		c, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{
+			ServerStream: ss,
+			ctx:          c.scope(ss.Context(), ""),
+			caller:       c,
+		})
+	}
+}
+
+// caller is the authenticated user of a call
+type caller struct {
+	subject string
+	role    string
+}
+
+// authenticate returns the subject and role of the call's bearer token.
+// Tokens without a role claim belong to regular users.
+func (a *Authenticator) authenticate(ctx context.Context) (caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := first(md, authorizationHeader)
+	raw, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || raw == "" {
+		return caller{}, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, a.key, jwt.WithValidMethods([]string{"HS256", "RS256"}))
+	if err != nil {
+		return caller{}, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return caller{}, status.Error(codes.Unauthenticated, "token has no subject")
+	}
+
+	role := RoleUser
+	if claim, ok := claims[roleClaim]; ok {
+		role, ok = claim.(string)
+		if !ok || !knownRole(role) {
+			return caller{}, status.Errorf(codes.Unauthenticated, "token has an unknown role %v", claim)
+		}
+	}
+	return caller{subject: subject, role: role}, nil
+}
+
+// key picks the verification key for the token's algorithm
+func (a *Authenticator) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(a.secret) > 0 {
+			return a.secret, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if a.publicKey != nil {
+			return a.publicKey, nil
+		}
+	}
+	return nil, errors.New("signing method not accepted")
+}
+
+// scope makes the caller the actor of everything the call touches and limits storage
+// access to the caller's own data, or for staff to the data of target
+func (c caller) scope(ctx context.Context, target string) context.Context {
+	ctx = appctx.WithRole(appctx.WithActor(ctx, c.subject), c.role)
+	if c.role == RoleUser {
+		return appctx.WithUserID(ctx, c.subject)
+	}
+	return appctx.WithUserID(ctx, target)
+}
+
+// checkUser rejects a request from a regular user that names a user other than the caller
+func (c caller) checkUser(req interface{}) error {
+	if c.role != RoleUser {
+		return nil
+	}
+	if userID := userIDField(req); userID != "" && userID != c.subject {
+		return status.Error(codes.PermissionDenied, "user_id does not match the authenticated user")
+	}
+	return nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	caller caller
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authenticatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.caller.checkUser(m)
+}
+
+// userIDField reads the user_id field of a request message, if it has one
+func userIDField(req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("user_id")
+	if field == nil {
+		return ""
+	}
+	return m.Get(field).String()
+}
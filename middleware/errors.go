@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"budget-service/appctx"
+	"budget-service/apperr"
+	"budget-service/storage"
+	"budget-service/validation"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies this service in ErrorInfo details
+const errorDomain = "budget-service"
+
+// StatusErrors turns the errors returned by services into gRPC statuses. Validation
+// failures become InvalidArgument with every field violation listed. Typed
+// errors get their matching code and details that say which entity, field or
+// rule was at fault; a version conflict becomes Aborted so the client knows to
+// re-read and retry. Anything else is logged with the request id and reported
+// as an internal error, without its text.
+func StatusErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		return nil, toStatus(ctx, info.FullMethod, err)
+	}
+}
+
+func toStatus(ctx context.Context, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var invalid *validation.Error
+	if errors.As(err, &invalid) {
+		detail := &errdetails.BadRequest{}
+		for _, v := range invalid.Violations {
+			detail.FieldViolations = append(detail.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		return withDetails(status.New(codes.InvalidArgument, invalid.Error()), detail)
+	}
+
+	if e, ok := apperr.As(err); ok {
+		code, detail := typedStatus(e)
+		return withDetails(status.New(code, e.Message), detail)
+	}
+
+	switch {
+	case errors.Is(err, storage.ErrVersionConflict):
+		detail := &errdetails.ErrorInfo{Reason: "VERSION_CONFLICT", Domain: errorDomain}
+		return withDetails(status.New(codes.Aborted, err.Error()), detail)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	log.Printf("%s failed (request %s): %v", method, appctx.RequestID(ctx), err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func typedStatus(e *apperr.Error) (codes.Code, protoadapt.MessageV1) {
+	switch e.Kind {
+	case apperr.KindNotFound:
+		return codes.NotFound, &errdetails.ResourceInfo{ResourceType: e.Subject, ResourceName: e.ID, Description: e.Message}
+	case apperr.KindAlreadyExists:
+		return codes.AlreadyExists, &errdetails.ResourceInfo{ResourceType: e.Subject, ResourceName: e.ID, Description: e.Message}
+	case apperr.KindInvalidArgument:
+		return codes.InvalidArgument, &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: e.Subject, Description: e.Message},
+		}}
+	case apperr.KindFailedPrecondition:
+		return codes.FailedPrecondition, &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: e.Subject, Subject: e.ID, Description: e.Message},
+		}}
+	}
+	return codes.Unknown, nil
+}
+
+func withDetails(st *status.Status, detail protoadapt.MessageV1) error {
+	if detail == nil {
+		return st.Err()
+	}
+	withDetail, err := st.WithDetails(detail)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetail.Err()
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"budget-service/appctx"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Roles a token can carry in its role claim
+const (
+	RoleUser    = "user"
+	RoleSupport = "support"
+	RoleAdmin   = "admin"
+)
+
+func knownRole(role string) bool {
+	return role == RoleUser || role == RoleSupport || role == RoleAdmin
+}
+
+// Policy says which roles may call each RPC. Methods are keyed by their full
+// name, e.g. /budget.AccountService/RecalculateBalances; those not listed are
+// open to the Default roles.
+type Policy struct {
+	Default []string            `yaml:"default"`
+	Methods map[string][]string `yaml:"methods"`
+}
+
+// LoadPolicy reads a policy from a YAML file
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file: %v", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse policy file: %v", err)
+	}
+	if err := checkRoles("default", p.Default); err != nil {
+		return nil, err
+	}
+	for method, roles := range p.Methods {
+		if err := checkRoles(method, roles); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func checkRoles(entry string, roles []string) error {
+	for _, role := range roles {
+		if !knownRole(role) {
+			return fmt.Errorf("policy %s: unknown role %q", entry, role)
+		}
+	}
+	return nil
+}
+
+// CheckMethods reports policy entries that don't name an RPC of the registered
+// services, so a typo can't silently leave a method on the default roles
+func (p *Policy) CheckMethods(services map[string]grpc.ServiceInfo) error {
+	known := map[string]bool{}
+	for name, info := range services {
+		for _, m := range info.Methods {
+			known["/"+name+"/"+m.Name] = true
+		}
+	}
+
+	var unknown []string
+	for method := range p.Methods {
+		if !known[method] {
+			unknown = append(unknown, method)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("policy names unknown methods: %v", unknown)
+	}
+	return nil
+}
+
+// Allows reports whether role may call method
+func (p *Policy) Allows(method, role string) bool {
+	roles, ok := p.Methods[method]
+	if !ok {
+		roles = p.Default
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize rejects calls whose role the policy doesn't allow. It runs after the
+// Authenticator, which puts the role into the context. Public services like
+// health checking are open to everyone.
+func Authorize(p *Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := p.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeStream does the same for streaming calls
+func AuthorizeStream(p *Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := p.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (p *Policy) check(ctx context.Context, method string) error {
+	if isPublic(method) {
+		return nil
+	}
+	if role := appctx.Role(ctx); !p.Allows(method, role) {
+		return status.Errorf(codes.PermissionDenied, "role %q may not call %s", role, method)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package middleware holds the gRPC interceptors shared by every service.
+package middleware
+
+import (
+	"context"
+
+	"budget-service/appctx"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const requestIDHeader = "x-request-id"
+
+// RequestContext puts the request id into the context. It is taken from the
+// x-request-id header or generated, and sent back in the response header.
+// The caller is added by the Authenticator.
+func RequestContext() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		requestID := first(md, requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+		ctx = appctx.WithRequestID(ctx, requestID)
+
+		return handler(ctx, req)
+	}
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
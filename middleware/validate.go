@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+
+	"budget-service/validation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Validate rejects requests that break the rules declared in the validation package
+// before they reach a service
+func Validate() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := validation.Validate(msg); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
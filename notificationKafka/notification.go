@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 
@@ -15,7 +16,7 @@ func StartLevel(rootService *service.NotificationService) func(message []byte) {
 			log.Printf("Cannot unmarshal JSON: %v", err)
 			return
 		}
-		err := rootService.CreateNotification(app)
+		err := rootService.CreateNotification(context.Background(), app)
 		if err != nil {
 			log.Printf("Cannot create evaluation via Kafka: %v", err)
 			return
@@ -23,4 +24,3 @@ func StartLevel(rootService *service.NotificationService) func(message []byte) {
 		log.Print("Created evaluation")
 	}
 }
-
@@ -4,4 +4,12 @@ rm -rf ${CURRENT_DIR}/protos
 for x in $(find ${CURRENT_DIR}/submodule-personal_finance* -type d); do
   protoc -I=${x} -I=${CURRENT_DIR} -I /usr/local/go --go_out=${CURRENT_DIR} \
    --go-grpc_out=${CURRENT_DIR} ${x}/*.proto
-done
\ No newline at end of file
+done
+# REST gateway handlers and the OpenAPI document for the services it exposes
+GATEWAY_PROTOS="account_managment budget_managment category_managment goal_managment transaction_managment notification report_management"
+HTTP_RULES=${CURRENT_DIR}/gateway/http.yaml
+protoc -I=${CURRENT_DIR}/submodule-personal_finance -I=${CURRENT_DIR} -I /usr/local/go \
+  --grpc-gateway_out=${CURRENT_DIR} --grpc-gateway_opt=grpc_api_configuration=${HTTP_RULES} \
+  --openapiv2_out=${CURRENT_DIR}/gateway \
+  --openapiv2_opt=grpc_api_configuration=${HTTP_RULES},openapi_configuration=${CURRENT_DIR}/gateway/openapi.yaml,allow_merge=true,merge_file_name=openapi,json_names_for_fields=false,output_format=json \
+  $(for p in ${GATEWAY_PROTOS}; do echo ${CURRENT_DIR}/submodule-personal_finance/${p}.proto; done)
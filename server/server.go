@@ -1,47 +1,205 @@
 package main
 
 import (
+	"budget-service/config"
+	"budget-service/gateway"
 	pb "budget-service/genproto"
+	"budget-service/health"
+	"budget-service/kafka"
+	"budget-service/lifecycle"
+	"budget-service/metrics"
+	"budget-service/middleware"
+	kaf "budget-service/notificationKafka"
 	"budget-service/service"
+	"budget-service/storage/audit"
 	postgres "budget-service/storage/mongo"
+	"budget-service/validation"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
-	"budget-service/kafka"
-	kaf "budget-service/notificationKafka"
-	"google.golang.org/grpc"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
 )
 
 func main() {
-	db, err := postgres.NewMongoConnection()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Error in configuration: ", err.Error())
+	}
+	if err := validation.CheckRules(); err != nil {
+		log.Fatal("Error in request validation rules: ", err.Error())
+	}
+
+	// Everything registered with lc is stopped in reverse order on SIGINT or SIGTERM
+	lc := lifecycle.New(cfg.ShutdownTimeout)
+
+	mongoDb, err := postgres.NewMongoConnection(cfg.MongoURI, cfg.MongoDatabase)
 	if err != nil {
 		log.Fatal("Error while connection on db: ", err.Error())
 	}
+	lc.OnStop("mongo client", mongoDb.Close)
+	// Every change made through db is recorded in the audit log
+	db := audit.New(mongoDb)
+	if cfg.ExchangeRatesFile != "" {
+		n, err := db.ExchangeRate().LoadExchangeRates(context.Background(), cfg.ExchangeRatesFile)
+		if err != nil {
+			log.Fatal("Error while loading exchange rates: ", err.Error())
+		}
+		log.Printf("loaded %d exchange rates from %s", n, cfg.ExchangeRatesFile)
+	}
+	producer, err := kafka.NewKafkaProducer(cfg.KafkaBrokers)
+	if err != nil {
+		log.Fatal("Error while connection kafka: ", err.Error())
+	}
+	lc.OnStop("kafka producer", func(context.Context) error { return producer.Close() })
+	notifier := service.NewNotifier(producer, cfg.KafkaTopic)
+
+	settings := service.NewUserSettingsService(db, cfg.BaseCurrency)
+	netWorth := service.NewNetWorthService(db, cfg.BaseCurrency, settings)
+	lc.Go("net worth snapshots", netWorth.RunDaily)
+	lc.Go("due date reminders", service.NewDueDateReminder(db, settings, notifier).RunDaily)
+	lc.Go("trash purger", service.NewTrashPurger(db, cfg.TrashRetentionDays).RunDaily)
+	lc.Go("budget metrics", service.NewBudgetMetrics(db).Run)
+
 	kcm := kafka.NewKafkaConsumerManager()
+	lc.OnStop("kafka consumers", func(context.Context) error { return kcm.Close() })
 	appService := service.NewNotificationService(db)
-	brokers := []string{"localhost:9092"}
-	if err := kcm.RegisterConsumer(brokers, "create", "root", kaf.StartLevel(appService)); err != nil {
+	if err := kcm.RegisterConsumer(cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaGroupID, kaf.StartLevel(appService)); err != nil {
 		if err == kafka.ErrConsumerAlreadyExists {
-			log.Printf("Consumer for topic 'notification' already exists")
+			log.Printf("Consumer for topic '%s' already exists", cfg.KafkaTopic)
 		} else {
 			log.Fatalf("Error registering consumer: %v", err)
 		}
 	}
 
-	liss, err := net.Listen("tcp", ":8088")
+	liss, err := net.Listen("tcp", cfg.GRPCPort)
 	if err != nil {
 		log.Fatal("Error while connection on tcp: ", err.Error())
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterAccountServiceServer(s, service.NewAccountService(db))
+	var publicKey []byte
+	if cfg.JWTPublicKeyFile != "" {
+		publicKey, err = os.ReadFile(cfg.JWTPublicKeyFile)
+		if err != nil {
+			log.Fatal("Error while reading JWT public key: ", err.Error())
+		}
+	}
+	auth, err := middleware.NewAuthenticator(cfg.TokenKey, publicKey)
+	if err != nil {
+		log.Fatal("Error while setting up authentication: ", err.Error())
+	}
+	policy, err := middleware.LoadPolicy(cfg.PolicyFile)
+	if err != nil {
+		log.Fatal("Error while loading access policy: ", err.Error())
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			middleware.Metrics(),
+			middleware.RequestContext(),
+			middleware.StatusErrors(),
+			auth.Unary(),
+			middleware.Authorize(policy),
+			middleware.Validate(),
+		),
+		grpc.ChainStreamInterceptor(middleware.MetricsStream(), auth.Stream(), middleware.AuthorizeStream(policy)),
+	)
+	pb.RegisterAccountServiceServer(s, service.NewAccountService(db, settings))
 	pb.RegisterCategoryServiceServer(s, service.NewCategoryService(db))
-	pb.RegisterTransactionServiceServer(s, service.NewTransactionService(db))
-	pb.RegisterGoalServiceServer(s, service.NewGoalService(db))
-	pb.RegisterBudgetServiceServer(s, service.NewBudgetService(db))
+	transactions := service.NewTransactionService(db, cfg.BaseCurrency, settings, notifier)
+	pb.RegisterTransactionServiceServer(s, transactions)
+	pb.RegisterGoalServiceServer(s, service.NewGoalService(db, settings))
+	pb.RegisterBudgetServiceServer(s, service.NewBudgetService(db, settings))
 	pb.RegisterNotificationtServiceServer(s, service.NewNotificationService(db))
-	log.Printf("server listening at %v", liss.Addr())
-	if err := s.Serve(liss); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	pb.RegisterExchangeRateServiceServer(s, service.NewExchangeRateService(db))
+	pb.RegisterReportServiceServer(s, service.NewReportService(db, cfg.BaseCurrency, settings))
+	pb.RegisterNetWorthServiceServer(s, netWorth)
+	pb.RegisterReconciliationServiceServer(s, service.NewReconciliationService(db))
+	pb.RegisterDebtServiceServer(s, service.NewDebtService(db, cfg.BaseCurrency))
+	pb.RegisterAuditServiceServer(s, service.NewAuditService(db))
+	pb.RegisterUserSettingsServiceServer(s, settings)
+	pb.RegisterHouseholdServiceServer(s, service.NewHouseholdService(db))
+	pb.RegisterSplitServiceServer(s, service.NewSplitService(db, transactions, cfg.BaseCurrency))
+
+	// Mongo backs every RPC; without Kafka only notifications are held up
+	checker := health.NewChecker(cfg.HealthCheckInterval)
+	checker.Add("mongo", true, mongoDb.Ping)
+	checker.Add("kafka", false, func(ctx context.Context) error { return kafka.CheckBrokers(ctx, cfg.KafkaBrokers) })
+	checker.Add("kafka-consumer", false, func(context.Context) error { return kcm.CheckLag(cfg.MaxConsumerLag) })
+	checker.Register(s)
+	if cfg.GRPCReflection {
+		reflection.Register(s)
+	}
+
+	if err := policy.CheckMethods(s.GetServiceInfo()); err != nil {
+		log.Fatal("Error in access policy: ", err.Error())
 	}
+	lc.OnStop("grpc server", func(ctx context.Context) error { return stopServer(ctx, s) })
 
+	gw, err := gateway.New(loopback(liss.Addr()))
+	if err != nil {
+		log.Fatal("Error while setting up the REST gateway: ", err.Error())
+	}
+	httpLiss, err := net.Listen("tcp", cfg.HTTPPort)
+	if err != nil {
+		log.Fatal("Error while connection on tcp: ", err.Error())
+	}
+	// The HTTP port also serves the metrics for Prometheus to scrape
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/", gw)
+	httpServer := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	lc.OnStop("http gateway", func(ctx context.Context) error {
+		defer gw.Close()
+		return httpServer.Shutdown(ctx)
+	})
+	lc.Go("health checks", checker.Run)
+	lc.OnStop("health status", checker.Shutdown)
+
+	go func() {
+		log.Printf("server listening at %v", liss.Addr())
+		if err := s.Serve(liss); err != nil {
+			log.Printf("failed to serve: %v", err)
+			lc.Shutdown()
+		}
+	}()
+	go func() {
+		log.Printf("REST gateway listening at %v", httpLiss.Addr())
+		if err := httpServer.Serve(httpLiss); err != http.ErrServerClosed {
+			log.Printf("failed to serve REST gateway: %v", err)
+			lc.Shutdown()
+		}
+	}()
+	lc.Wait()
+}
+
+// loopback is the address the gateway reaches the gRPC server listening on addr at
+func loopback(addr net.Addr) string {
+	tcp := addr.(*net.TCPAddr)
+	if tcp.IP.IsUnspecified() {
+		return net.JoinHostPort("localhost", strconv.Itoa(tcp.Port))
+	}
+	return tcp.String()
+}
+
+// stopServer lets in-flight RPCs finish, cutting off whatever is still running
+// when ctx is done
+func stopServer(ctx context.Context, s *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
 }
@@ -9,25 +9,61 @@ import (
 )
 
 type AccountService struct {
-	stg mdb.InitRoot
+	stg      mdb.InitRoot
+	settings *UserSettingsService
 	pb.UnimplementedAccountServiceServer
 }
 
-func NewAccountService(db mdb.InitRoot) *AccountService {
-	return &AccountService{stg: db}
+func NewAccountService(db mdb.InitRoot, settings *UserSettingsService) *AccountService {
+	return &AccountService{stg: db, settings: settings}
 }
 
 func (s *AccountService) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountRes, error) {
-	resp, err := s.stg.Account().CreateAccount(req)
+	if err := checkEditable(ctx, s.stg, req.HouseholdId, req.UserId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	existing, err := s.stg.Account().ListAccounts(ctx, &pb.ListAccountsRequest{UserId: req.UserId, IncludeArchived: true})
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
+
+	resp, err := s.stg.Account().CreateAccount(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// The first account of their own marks a new user, so give them the default
+	// categories in their language. Accounts shared by their households don't count.
+	owns := false
+	for _, account := range existing.Accounts {
+		owns = owns || account.UserId == req.UserId
+	}
+	if !owns {
+		s.seedCategories(ctx, req.UserId)
+	}
 	return resp, nil
 }
 
+// seedCategories gives a new user the default categories for their locale. A
+// failure only costs them the defaults, so it doesn't fail the new account.
+func (s *AccountService) seedCategories(ctx context.Context, userId string) {
+	settings, err := s.settings.forUser(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to seed default categories: %v", err)
+		return
+	}
+	_, err = s.stg.Category().SeedDefaultCategories(ctx, &pb.SeedDefaultCategoriesRequest{UserId: userId, Locale: settings.Locale})
+	if err != nil {
+		log.Printf("Failed to seed default categories: %v", err)
+	}
+}
+
 func (s *AccountService) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
-	resp, err := s.stg.Account().ListAccounts(req)
+	resp, err := s.stg.Account().ListAccounts(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -36,7 +72,7 @@ func (s *AccountService) ListAccounts(ctx context.Context, req *pb.ListAccountsR
 }
 
 func (s *AccountService) GetAccountById(ctx context.Context, req *pb.GetAccountByIdRequest) (*pb.AccountResponse, error) {
-	resp, err := s.stg.Account().GetAccountById(req)
+	resp, err := s.stg.Account().GetAccountById(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -45,7 +81,7 @@ func (s *AccountService) GetAccountById(ctx context.Context, req *pb.GetAccountB
 }
 
 func (s *AccountService) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.CreateAccountRes, error) {
-	resp, err := s.stg.Account().UpdateAccount(req)
+	resp, err := s.stg.Account().UpdateAccount(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -54,10 +90,94 @@ func (s *AccountService) UpdateAccount(ctx context.Context, req *pb.UpdateAccoun
 }
 
 func (s *AccountService) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteResponse, error) {
-	resp, err := s.stg.Account().DeleteAccount(req)
+	resp, err := s.stg.Account().DeleteAccount(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 	return resp, nil
 }
+
+// ArchiveAccount closes an account: it is hidden from ListAccounts and takes no new
+// transactions, but its history stays in reports
+func (s *AccountService) ArchiveAccount(ctx context.Context, req *pb.ArchiveAccountRequest) (*pb.CreateAccountRes, error) {
+	if err := s.stg.Account().SetArchived(ctx, req.AccountId, true); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.CreateAccountRes{Message: "Account archived successfully"}, nil
+}
+
+func (s *AccountService) ReopenAccount(ctx context.Context, req *pb.ReopenAccountRequest) (*pb.CreateAccountRes, error) {
+	if err := s.stg.Account().SetArchived(ctx, req.AccountId, false); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.CreateAccountRes{Message: "Account reopened successfully"}, nil
+}
+
+// RestoreAccount takes a deleted account and its transactions out of the trash
+func (s *AccountService) RestoreAccount(ctx context.Context, req *pb.RestoreAccountRequest) (*pb.CreateAccountRes, error) {
+	if err := s.stg.Account().RestoreAccount(ctx, req.AccountId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.CreateAccountRes{Message: "Account restored successfully"}, nil
+}
+
+// RecalculateBalances rebuilds balances from the opening balance and the transaction
+// ledger. Discrepancies are always reported; they are only written back when req.Fix is set.
+func (s *AccountService) RecalculateBalances(ctx context.Context, req *pb.RecalculateBalancesRequest) (*pb.RecalculateBalancesResponse, error) {
+	var accounts []*pb.AccountResponse
+	if req.AccountId != "" {
+		account, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.AccountId})
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	} else {
+		resp, err := s.stg.Account().ListAccounts(ctx, &pb.ListAccountsRequest{UserId: req.UserId, IncludeArchived: true})
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		accounts = resp.Accounts
+	}
+
+	resp := &pb.RecalculateBalancesResponse{}
+	for _, account := range accounts {
+		transactions, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{AccountId: account.AccountId})
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+
+		computed := account.OpeningBalance
+		for _, t := range transactions.Transactions {
+			computed += transactionEffect(account.AccountType, t.Type, float64(t.Amount))
+		}
+		computed = roundCents(computed)
+		resp.Checked++
+
+		if computed == roundCents(account.Balance) {
+			continue
+		}
+		resp.Discrepancies = append(resp.Discrepancies, &pb.BalanceDiscrepancy{
+			AccountId:       account.AccountId,
+			UserId:          account.UserId,
+			StoredBalance:   account.Balance,
+			ComputedBalance: computed,
+			Difference:      roundCents(computed - account.Balance),
+		})
+
+		if req.Fix {
+			if err := s.stg.Account().SetBalance(ctx, account.AccountId, computed); err != nil {
+				log.Print(err)
+				return nil, err
+			}
+			resp.Fixed++
+		}
+	}
+	return resp, nil
+}
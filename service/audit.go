@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+type AuditService struct {
+	stg mdb.InitRoot
+	pb.UnimplementedAuditServiceServer
+}
+
+func NewAuditService(db mdb.InitRoot) *AuditService {
+	return &AuditService{stg: db}
+}
+
+// ListAuditEvents answers who changed what and when, newest first
+func (s *AuditService) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	resp, err := s.stg.AuditLog().ListAuditEvents(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+const (
+	transferOut = "transfer_out"
+	transferIn  = "transfer_in"
+)
+
+// liabilityAccountTypes hold money owed, so their balance is the amount owed
+var liabilityAccountTypes = map[string]bool{
+	"credit_card": true,
+	"loan":        true,
+}
+
+func isLiability(accountType string) bool {
+	return liabilityAccountTypes[accountType]
+}
+
+func isTransfer(transactionType string) bool {
+	return transactionType == transferOut || transactionType == transferIn
+}
+
+// isOutflow reports whether money leaves the account: spending or the sending side of a transfer
+func isOutflow(transactionType string) bool {
+	return transactionType == "-" || transactionType == transferOut
+}
+
+// transactionEffect is how a transaction changes its account's balance.
+// Spending lowers an asset balance but raises the amount owed on a liability.
+func transactionEffect(accountType, transactionType string, amount float64) float64 {
+	effect := amount
+	if isOutflow(transactionType) {
+		effect = -amount
+	}
+	if isLiability(accountType) {
+		effect = -effect
+	}
+	return effect
+}
+
+// adjustmentType picks the transaction type that changes the balance by a positive or negative difference
+func adjustmentType(accountType string, difference float64) string {
+	if transactionEffect(accountType, "+", 1) > 0 == (difference > 0) {
+		return "+"
+	}
+	return "-"
+}
+
+// applyToBalance books a transaction's effect on the stored account balance
+func applyToBalance(ctx context.Context, stg mdb.InitRoot, account *pb.AccountResponse, transactionType string, amount float32) error {
+	if transactionEffect(account.AccountType, transactionType, float64(amount)) < 0 {
+		return stg.Account().UpdateBalanceMinus(ctx, account.AccountId, amount)
+	}
+	return stg.Account().UpdateBalance(ctx, account.AccountId, amount)
+}
+
+// revertFromBalance takes a transaction's effect back off the stored account balance
+func revertFromBalance(ctx context.Context, stg mdb.InitRoot, account *pb.AccountResponse, transactionType string, amount float32) error {
+	if transactionEffect(account.AccountType, transactionType, float64(amount)) < 0 {
+		return stg.Account().UpdateBalance(ctx, account.AccountId, amount)
+	}
+	return stg.Account().UpdateBalanceMinus(ctx, account.AccountId, amount)
+}
+
+// checkOpen rejects new transactions against archived accounts
+func checkOpen(account *pb.AccountResponse) error {
+	if account.Archived {
+		return apperr.FailedPrecondition("account_open", "account %s is archived", account.AccountId)
+	}
+	return nil
+}
+
+// roundCents drops float noise from sums of money
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -3,22 +3,60 @@ package service
 import (
 	"context"
 	"log"
+	"strings"
 
+	"budget-service/apperr"
+	"budget-service/dates"
 	pb "budget-service/genproto"
 	mdb "budget-service/storage"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type BudgetService struct {
-	stg mdb.InitRoot
+	stg      mdb.InitRoot
+	settings *UserSettingsService
 	pb.UnimplementedBudgetServiceServer
 }
 
-func NewBudgetService(db mdb.InitRoot) *BudgetService {
-	return &BudgetService{stg: db}
+func NewBudgetService(db mdb.InitRoot, settings *UserSettingsService) *BudgetService {
+	return &BudgetService{stg: db, settings: settings}
 }
 
+// CreateBudget evaluates the budget in the user's time zone unless the request names
+// one. Without dates it covers the current daily, weekly, monthly or yearly period.
 func (s *BudgetService) CreateBudget(ctx context.Context, req *pb.CreateBudgetRequest) (*pb.MessageResponsee, error) {
-	resp, err := s.stg.Budget().CreateBudget(req)
+	if err := checkEditable(ctx, s.stg, req.HouseholdId, req.UserId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	now, weekStart, err := s.settings.now(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if req.TimeZone == "" {
+		req.TimeZone = now.Location().String()
+	}
+
+	if req.StartDate == nil && req.EndDate == nil {
+		unit, ok := budgetPeriods[strings.ToLower(req.Period)]
+		if !ok {
+			err := apperr.InvalidArgument("start_date", "start_date and end_date are required unless period is daily, weekly, monthly or yearly")
+			log.Print(err)
+			return nil, err
+		}
+		start, end, _ := periodBounds(now.In(dates.Location(req.TimeZone)), unit, weekStart)
+		req.StartDate = timestamppb.New(start)
+		req.EndDate = timestamppb.New(end)
+	} else if req.StartDate == nil || req.EndDate == nil {
+		err := apperr.InvalidArgument("end_date", "start_date and end_date must be given together")
+		log.Print(err)
+		return nil, err
+	}
+
+	resp, err := s.stg.Budget().CreateBudget(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -27,7 +65,7 @@ func (s *BudgetService) CreateBudget(ctx context.Context, req *pb.CreateBudgetRe
 }
 
 func (s *BudgetService) ListBudgets(ctx context.Context, req *pb.ListBudgetsRequest) (*pb.ListBudgetsResponse, error) {
-	resp, err := s.stg.Budget().ListBudgets(req)
+	resp, err := s.stg.Budget().ListBudgets(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -36,7 +74,7 @@ func (s *BudgetService) ListBudgets(ctx context.Context, req *pb.ListBudgetsRequ
 }
 
 func (s *BudgetService) GetBudgetById(ctx context.Context, req *pb.GetBudgetByIdRequest) (*pb.BudgetResponse, error) {
-	resp, err := s.stg.Budget().GetBudgetById(req)
+	resp, err := s.stg.Budget().GetBudgetById(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -45,7 +83,7 @@ func (s *BudgetService) GetBudgetById(ctx context.Context, req *pb.GetBudgetById
 }
 
 func (s *BudgetService) UpdateBudget(ctx context.Context, req *pb.UpdateBudgetRequest) (*pb.MessageResponsee, error) {
-	resp, err := s.stg.Budget().UpdateBudget(req)
+	resp, err := s.stg.Budget().UpdateBudget(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -54,10 +92,18 @@ func (s *BudgetService) UpdateBudget(ctx context.Context, req *pb.UpdateBudgetRe
 }
 
 func (s *BudgetService) DeleteBudget(ctx context.Context, req *pb.DeleteBudgetRequest) (*pb.BudgetDeleteResponse, error) {
-	resp, err := s.stg.Budget().DeleteBudget(req)
+	resp, err := s.stg.Budget().DeleteBudget(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 	return resp, nil
 }
+
+func (s *BudgetService) RestoreBudget(ctx context.Context, req *pb.RestoreBudgetRequest) (*pb.MessageResponsee, error) {
+	if err := s.stg.Budget().RestoreBudget(ctx, req.BudgetId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.MessageResponsee{Message: "Budget restored successfully"}, nil
+}
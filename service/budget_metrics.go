@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"budget-service/metrics"
+	mdb "budget-service/storage"
+)
+
+// budgetMetricsInterval is how often the budget gauges are refreshed
+const budgetMetricsInterval = time.Minute
+
+// BudgetMetrics keeps the budget gauges of the metrics endpoint up to date
+type BudgetMetrics struct {
+	stg mdb.InitRoot
+}
+
+func NewBudgetMetrics(db mdb.InitRoot) *BudgetMetrics {
+	return &BudgetMetrics{stg: db}
+}
+
+// Update counts the active budgets and those over their limit across all users
+func (m *BudgetMetrics) Update(ctx context.Context, now time.Time) error {
+	active, overLimit, err := m.stg.Budget().CountActive(ctx, now)
+	if err != nil {
+		return err
+	}
+	metrics.BudgetsActive.Set(float64(active))
+	metrics.BudgetsOverLimit.Set(float64(overLimit))
+	return nil
+}
+
+// Run updates the gauges right away and then every minute until ctx is cancelled
+func (m *BudgetMetrics) Run(ctx context.Context) {
+	ticker := time.NewTicker(budgetMetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Update(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to update budget metrics: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
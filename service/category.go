@@ -18,7 +18,7 @@ func NewCategoryService(db mdb.InitRoot) *CategoryService {
 }
 
 func (s *CategoryService) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.MessageResponse, error) {
-	resp, err := s.stg.Category().CreateCategory(req)
+	resp, err := s.stg.Category().CreateCategory(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -27,7 +27,7 @@ func (s *CategoryService) CreateCategory(ctx context.Context, req *pb.CreateCate
 }
 
 func (s *CategoryService) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListResponse, error) {
-	resp, err := s.stg.Category().ListCategories(req)
+	resp, err := s.stg.Category().ListCategories(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -36,7 +36,7 @@ func (s *CategoryService) ListCategories(ctx context.Context, req *pb.ListCatego
 }
 
 func (s *CategoryService) GetCategoryById(ctx context.Context, req *pb.GetCategoryByIdRequest) (*pb.CategoryResponse, error) {
-	resp, err := s.stg.Category().GetCategoryById(req)
+	resp, err := s.stg.Category().GetCategoryById(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -45,7 +45,7 @@ func (s *CategoryService) GetCategoryById(ctx context.Context, req *pb.GetCatego
 }
 
 func (s *CategoryService) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.MessageResponse, error) {
-	resp, err := s.stg.Category().UpdateCategory(req)
+	resp, err := s.stg.Category().UpdateCategory(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -54,10 +54,36 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, req *pb.UpdateCate
 }
 
 func (s *CategoryService) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.CategoryDeleteResponse, error) {
-	resp, err := s.stg.Category().DeleteCategory(req)
+	resp, err := s.stg.Category().DeleteCategory(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 	return resp, nil
 }
+
+func (s *CategoryService) ListCategoryTemplates(ctx context.Context, req *pb.ListCategoryTemplatesRequest) (*pb.ListCategoryTemplatesResponse, error) {
+	resp, err := s.stg.Category().ListCategoryTemplates(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *CategoryService) SeedDefaultCategories(ctx context.Context, req *pb.SeedDefaultCategoriesRequest) (*pb.SeedDefaultCategoriesResponse, error) {
+	resp, err := s.stg.Category().SeedDefaultCategories(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *CategoryService) RestoreCategory(ctx context.Context, req *pb.RestoreCategoryRequest) (*pb.MessageResponse, error) {
+	if err := s.stg.Category().RestoreCategory(ctx, req.CategoryId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.MessageResponse{Message: "Category restored successfully"}, nil
+}
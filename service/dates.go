@@ -0,0 +1,76 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"budget-service/dates"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dayIn returns the calendar day ts falls on in the given time zone.
+// An unset timestamp gives an empty day, which exchange-rate lookups read as "latest".
+func dayIn(ts *timestamppb.Timestamp, zone string) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().In(dates.Location(zone)).Format(dates.Layout)
+}
+
+// weekday parses a day name such as "monday", defaulting to Monday
+func weekday(name string) time.Weekday {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), name) {
+			return d
+		}
+	}
+	return time.Monday
+}
+
+// budgetPeriods maps the budget period names to the unit they span
+var budgetPeriods = map[string]string{
+	"daily":   "day",
+	"weekly":  "week",
+	"monthly": "month",
+	"yearly":  "year",
+}
+
+// periodStart returns the start of the day, week, month or year that t falls in,
+// in t's location. Weeks start on weekStart. Unknown units give false.
+func periodStart(t time.Time, unit string, weekStart time.Weekday) (time.Time, bool) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch unit {
+	case "day":
+		return day, true
+	case "week":
+		offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+		return day.AddDate(0, 0, -offset), true
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()), true
+	case "year":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// periodBounds returns the first and last moment of the unit that contains t.
+// The end is inclusive like budget end dates, to the millisecond MongoDB keeps.
+func periodBounds(t time.Time, unit string, weekStart time.Weekday) (time.Time, time.Time, bool) {
+	start, ok := periodStart(t, unit, weekStart)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	var next time.Time
+	switch unit {
+	case "day":
+		next = start.AddDate(0, 0, 1)
+	case "week":
+		next = start.AddDate(0, 0, 7)
+	case "month":
+		next = start.AddDate(0, 1, 0)
+	case "year":
+		next = start.AddDate(1, 0, 0)
+	}
+	return start, next.Add(-time.Millisecond), true
+}
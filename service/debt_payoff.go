@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"budget-service/apperr"
+	"budget-service/dates"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+const (
+	strategyAvalanche = "avalanche"
+	strategySnowball  = "snowball"
+
+	// maxPayoffMonths stops plans that would take longer than 50 years
+	maxPayoffMonths = 600
+)
+
+type DebtService struct {
+	stg          mdb.InitRoot
+	baseCurrency string
+	pb.UnimplementedDebtServiceServer
+}
+
+func NewDebtService(db mdb.InitRoot, baseCurrency string) *DebtService {
+	return &DebtService{stg: db, baseCurrency: baseCurrency}
+}
+
+// debt is a liability being paid down during a simulation
+type debt struct {
+	accountId      string
+	name           string
+	balance        float64
+	apr            float64
+	minimum        float64
+	startBalance   float64
+	interestPaid   float64
+	monthsToPayoff int32
+}
+
+// PlanDebtPayoff compares paying the highest APR first (avalanche) with paying the
+// smallest balance first (snowball) for the user's credit cards and loans
+func (s *DebtService) PlanDebtPayoff(ctx context.Context, req *pb.PlanDebtPayoffRequest) (*pb.PlanDebtPayoffResponse, error) {
+	accounts, err := s.stg.Account().ListAccounts(ctx, &pb.ListAccountsRequest{UserId: req.UserId})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	today := time.Now().Format(dates.Layout)
+	var debts []debt
+	var minimums float64
+	for _, a := range accounts.Accounts {
+		if !isLiability(a.AccountType) || a.Balance <= 0 {
+			continue
+		}
+		// Plans are made in the base currency so debts in different currencies share one budget
+		balance, err := convertAmount(ctx, s.stg, a.Balance, a.Currency, s.baseCurrency, s.baseCurrency, today)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		minimum, err := convertAmount(ctx, s.stg, minimumPayment(a), a.Currency, s.baseCurrency, s.baseCurrency, today)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		debts = append(debts, debt{
+			accountId:    a.AccountId,
+			name:         a.AccountName,
+			balance:      balance,
+			apr:          a.Apr,
+			minimum:      minimum,
+			startBalance: balance,
+		})
+		minimums += minimum
+	}
+
+	if len(debts) > 0 && req.MonthlyBudget < minimums {
+		return nil, apperr.InvalidArgument("monthly_budget", "monthly budget %.2f is below the minimum payments of %.2f", req.MonthlyBudget, minimums)
+	}
+
+	start := time.Now()
+	return &pb.PlanDebtPayoffResponse{
+		Currency:        s.baseCurrency,
+		MinimumPayments: roundCents(minimums),
+		Avalanche:       simulatePayoff(strategyAvalanche, debts, req.MonthlyBudget, start),
+		Snowball:        simulatePayoff(strategySnowball, debts, req.MonthlyBudget, start),
+	}, nil
+}
+
+// simulatePayoff runs the plan month by month: interest accrues, every debt gets
+// its minimum payment and whatever is left of the budget goes to the debts in
+// strategy order, rolling over to the next one as each is paid off. Months are
+// labelled from the month after start. A budget below the minimum payments has
+// no plan.
+func simulatePayoff(strategy string, input []debt, budget float64, start time.Time) *pb.PayoffPlan {
+	debts := make([]debt, len(input))
+	copy(debts, input)
+	// Adding months to the 29th-31st would skip short months
+	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+
+	order := make([]int, len(debts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		da, db := debts[order[a]], debts[order[b]]
+		if strategy == strategyAvalanche {
+			return da.apr > db.apr
+		}
+		return da.balance < db.balance
+	})
+
+	plan := &pb.PayoffPlan{Strategy: strategy, Feasible: true}
+	remaining := func() float64 {
+		var total float64
+		for _, d := range debts {
+			total += d.balance
+		}
+		return total
+	}
+
+	var minimums float64
+	for _, d := range debts {
+		minimums += d.minimum
+	}
+	if budget < roundCents(minimums) {
+		plan.Feasible = false
+	}
+
+	for month := int32(1); plan.Feasible && remaining() > 0.005; month++ {
+		if month > maxPayoffMonths {
+			plan.Feasible = false
+			break
+		}
+		before := remaining()
+
+		payments := make([]float64, len(debts))
+		interest := make([]float64, len(debts))
+		left := budget
+		for i := range debts {
+			if debts[i].balance <= 0 {
+				continue
+			}
+			interest[i] = roundCents(debts[i].balance * debts[i].apr / 100 / 12)
+			debts[i].balance += interest[i]
+			debts[i].interestPaid += interest[i]
+
+			pay := debts[i].minimum
+			if pay > debts[i].balance {
+				pay = debts[i].balance
+			}
+			payments[i] = pay
+			debts[i].balance -= pay
+			left -= pay
+		}
+		for _, i := range order {
+			if left <= 0 {
+				break
+			}
+			if debts[i].balance <= 0 {
+				continue
+			}
+			pay := left
+			if pay > debts[i].balance {
+				pay = debts[i].balance
+			}
+			payments[i] += pay
+			debts[i].balance -= pay
+			left -= pay
+		}
+
+		date := start.AddDate(0, int(month), 0).Format("2006-01")
+		schedule := &pb.PayoffMonth{Month: month, Date: date}
+		for i := range debts {
+			if payments[i] == 0 && interest[i] == 0 {
+				continue
+			}
+			debts[i].balance = roundCents(debts[i].balance)
+			if debts[i].balance <= 0 && debts[i].monthsToPayoff == 0 {
+				debts[i].monthsToPayoff = month
+			}
+			schedule.Payments = append(schedule.Payments, &pb.DebtPayment{
+				AccountId:        debts[i].accountId,
+				Payment:          roundCents(payments[i]),
+				Interest:         interest[i],
+				RemainingBalance: debts[i].balance,
+			})
+			schedule.TotalPaid += payments[i]
+		}
+		schedule.TotalPaid = roundCents(schedule.TotalPaid)
+		plan.TotalPaid += schedule.TotalPaid
+		plan.Schedule = append(plan.Schedule, schedule)
+		plan.Months = month
+		plan.PayoffDate = date
+
+		// A budget that only covers the interest never pays the debts off
+		if remaining() >= before {
+			plan.Feasible = false
+			break
+		}
+	}
+
+	for _, i := range order {
+		d := debts[i]
+		summary := &pb.DebtSummary{
+			AccountId:       d.accountId,
+			AccountName:     d.name,
+			StartingBalance: roundCents(d.startBalance),
+			Apr:             d.apr,
+			InterestPaid:    roundCents(d.interestPaid),
+			MonthsToPayoff:  d.monthsToPayoff,
+		}
+		if d.monthsToPayoff > 0 {
+			summary.PayoffDate = start.AddDate(0, int(d.monthsToPayoff), 0).Format("2006-01")
+		}
+		plan.Debts = append(plan.Debts, summary)
+		plan.TotalInterest += d.interestPaid
+	}
+	plan.TotalInterest = roundCents(plan.TotalInterest)
+	plan.TotalPaid = roundCents(plan.TotalPaid)
+	if !plan.Feasible {
+		plan.PayoffDate = ""
+	}
+	return plan
+}
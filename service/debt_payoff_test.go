@@ -0,0 +1,133 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func newDebt(id string, balance, apr, minimum float64) debt {
+	return debt{accountId: id, name: id, balance: balance, apr: apr, minimum: minimum, startBalance: balance}
+}
+
+func TestSimulatePayoffOrder(t *testing.T) {
+	// The card has the higher rate, the loan the smaller balance
+	debts := []debt{newDebt("loan", 500, 10, 25), newDebt("card", 1000, 25, 50)}
+	start := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		strategy     string
+		first, later string
+	}{
+		{strategyAvalanche, "card", "loan"},
+		{strategySnowball, "loan", "card"},
+	}
+	interest := map[string]float64{}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			plan := simulatePayoff(tt.strategy, debts, 200, start)
+			if !plan.Feasible {
+				t.Fatal("plan is not feasible")
+			}
+			if len(plan.Debts) != 2 || plan.Debts[0].AccountId != tt.first || plan.Debts[1].AccountId != tt.later {
+				t.Fatalf("debts = %v, want %s then %s", plan.Debts, tt.first, tt.later)
+			}
+			if plan.Debts[0].MonthsToPayoff >= plan.Debts[1].MonthsToPayoff {
+				t.Errorf("%s paid off in month %d, not before %s in month %d",
+					tt.first, plan.Debts[0].MonthsToPayoff, tt.later, plan.Debts[1].MonthsToPayoff)
+			}
+			if plan.Months != plan.Debts[1].MonthsToPayoff {
+				t.Errorf("plan takes %d months, last debt is paid off in month %d", plan.Months, plan.Debts[1].MonthsToPayoff)
+			}
+			interest[tt.strategy] = plan.TotalInterest
+		})
+	}
+	if interest[strategyAvalanche] > interest[strategySnowball] {
+		t.Errorf("avalanche interest %.2f is more than snowball interest %.2f", interest[strategyAvalanche], interest[strategySnowball])
+	}
+
+	// The input is left as it was
+	if debts[0].balance != 500 || debts[1].balance != 1000 {
+		t.Errorf("simulatePayoff changed its input: %v", debts)
+	}
+}
+
+func TestSimulatePayoffRollover(t *testing.T) {
+	debts := []debt{newDebt("small", 100, 0, 10), newDebt("big", 300, 0, 10)}
+	plan := simulatePayoff(strategySnowball, debts, 110, time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC))
+
+	// Month 1 pays small off; from month 2 on its minimum and the extra go to big
+	want := []map[string]float64{
+		{"small": 100, "big": 10},
+		{"big": 110},
+		{"big": 110},
+		{"big": 70},
+	}
+	if len(plan.Schedule) != len(want) {
+		t.Fatalf("schedule has %d months, want %d", len(plan.Schedule), len(want))
+	}
+	for m, month := range plan.Schedule {
+		if len(month.Payments) != len(want[m]) {
+			t.Fatalf("month %d has payments %v, want %v", month.Month, month.Payments, want[m])
+		}
+		for _, p := range month.Payments {
+			if p.Payment != want[m][p.AccountId] {
+				t.Errorf("month %d pays %s %.2f, want %.2f", month.Month, p.AccountId, p.Payment, want[m][p.AccountId])
+			}
+		}
+	}
+	if plan.TotalPaid != 400 || plan.TotalInterest != 0 {
+		t.Errorf("total paid %.2f with interest %.2f, want 400 and 0", plan.TotalPaid, plan.TotalInterest)
+	}
+}
+
+func TestSimulatePayoffBudgetBelowMinimums(t *testing.T) {
+	debts := []debt{newDebt("card", 1000, 20, 50), newDebt("loan", 2000, 8, 60)}
+	plan := simulatePayoff(strategyAvalanche, debts, 100, time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC))
+
+	if plan.Feasible {
+		t.Error("plan is feasible")
+	}
+	if len(plan.Schedule) != 0 || plan.PayoffDate != "" || plan.TotalPaid != 0 {
+		t.Errorf("plan has a schedule of %d months, payoff date %q and total paid %.2f", len(plan.Schedule), plan.PayoffDate, plan.TotalPaid)
+	}
+	for _, d := range plan.Debts {
+		if d.MonthsToPayoff != 0 || d.PayoffDate != "" {
+			t.Errorf("debt %s is paid off in month %d (%s)", d.AccountId, d.MonthsToPayoff, d.PayoffDate)
+		}
+	}
+}
+
+func TestSimulatePayoffInterestOnly(t *testing.T) {
+	// 1% a month on 1200 is 12, which the minimum only just covers
+	debts := []debt{newDebt("card", 1200, 12, 12)}
+	plan := simulatePayoff(strategyAvalanche, debts, 12, time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC))
+	if plan.Feasible || plan.PayoffDate != "" {
+		t.Errorf("plan is feasible with payoff date %q", plan.PayoffDate)
+	}
+}
+
+func TestSimulatePayoffMonthLabels(t *testing.T) {
+	debts := []debt{newDebt("card", 300, 0, 100)}
+	for _, day := range []int{1, 15, 29, 30, 31} {
+		start := time.Date(2024, time.January, day, 12, 0, 0, 0, time.UTC)
+		plan := simulatePayoff(strategyAvalanche, debts, 100, start)
+
+		var got []string
+		for _, month := range plan.Schedule {
+			got = append(got, month.Date)
+		}
+		want := []string{"2024-02", "2024-03", "2024-04"}
+		if len(got) != len(want) {
+			t.Fatalf("January %d: months %v, want %v", day, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("January %d: months %v, want %v", day, got, want)
+				break
+			}
+		}
+		if plan.PayoffDate != "2024-04" || plan.Debts[0].PayoffDate != "2024-04" {
+			t.Errorf("January %d: payoff dates %q and %q, want 2024-04", day, plan.PayoffDate, plan.Debts[0].PayoffDate)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"budget-service/dates"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+// dueReminderDays is how many days before the payment due date the reminder goes out
+const dueReminderDays = 3
+
+// DueDateReminder notifies users about upcoming credit card and loan payments
+type DueDateReminder struct {
+	stg      mdb.InitRoot
+	settings *UserSettingsService
+	notifier *Notifier
+}
+
+func NewDueDateReminder(db mdb.InitRoot, settings *UserSettingsService, notifier *Notifier) *DueDateReminder {
+	return &DueDateReminder{stg: db, settings: settings, notifier: notifier}
+}
+
+// nextDueDate is the first payment due date on or after today. Due days past
+// the end of a short month fall on its last day.
+func nextDueDate(dueDay int32, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := 0; i < 2; i++ {
+		month := time.Date(today.Year(), today.Month()+time.Month(i), 1, 0, 0, 0, 0, now.Location())
+		lastDay := month.AddDate(0, 1, -1).Day()
+		day := int(dueDay)
+		if day > lastDay {
+			day = lastDay
+		}
+		due := time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, now.Location())
+		if !due.Before(today) {
+			return due
+		}
+	}
+	return today
+}
+
+// minimumPayment is the account's configured minimum payment, or one month of
+// interest plus 1% of the balance when none is set, capped at the amount owed
+func minimumPayment(account *pb.AccountResponse) float64 {
+	payment := account.MinimumPayment
+	if payment <= 0 {
+		payment = account.Balance*account.Apr/100/12 + account.Balance*0.01
+	}
+	if payment > account.Balance {
+		payment = account.Balance
+	}
+	return roundCents(payment)
+}
+
+// SendReminders sends a notification for every liability with a balance whose
+// payment is due in dueReminderDays. Days are counted in each owner's own time
+// zone, and every due date is reminded of once even if this runs again that day.
+func (r *DueDateReminder) SendReminders(ctx context.Context, now time.Time) error {
+	accounts, err := r.stg.Account().ListAccounts(ctx, &pb.ListAccountsRequest{})
+	if err != nil {
+		return err
+	}
+
+	zones := make(map[string]*time.Location)
+	for _, a := range accounts.Accounts {
+		if !isLiability(a.AccountType) || a.PaymentDueDay == 0 || a.Balance <= 0 {
+			continue
+		}
+		zone, ok := zones[a.UserId]
+		if !ok {
+			settings, err := r.settings.forUser(ctx, a.UserId)
+			if err != nil {
+				return err
+			}
+			zone = dates.Location(settings.TimeZone)
+			zones[a.UserId] = zone
+		}
+
+		today := now.In(zone)
+		due := nextDueDate(a.PaymentDueDay, today).Format(dates.Layout)
+		if due != today.AddDate(0, 0, dueReminderDays).Format(dates.Layout) {
+			continue
+		}
+
+		first, err := r.stg.Account().SetRemindedDue(ctx, a.AccountId, due)
+		if err != nil {
+			log.Printf("Failed to record due date reminder for account %s: %v", a.AccountId, err)
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		message := fmt.Sprintf("Your %s payment is due on %s: minimum %.2f %s, balance %.2f %s",
+			a.AccountName, due, minimumPayment(a), a.Currency, a.Balance, a.Currency)
+		if err := r.notifier.Notify(a.UserId, message); err != nil {
+			log.Printf("Failed to send due date reminder for account %s: %v", a.AccountId, err)
+			// Let the next run try again
+			if _, err := r.stg.Account().SetRemindedDue(ctx, a.AccountId, ""); err != nil {
+				log.Printf("Failed to reset due date reminder for account %s: %v", a.AccountId, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RunDaily checks for upcoming payments right away and then once a day until ctx is cancelled.
+// Reminders already sent are recorded on the account, so a restart doesn't repeat them.
+func (r *DueDateReminder) RunDaily(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := r.SendReminders(ctx, time.Now()); err != nil {
+			log.Printf("Failed to send due date reminders: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+type ExchangeRateService struct {
+	stg mdb.InitRoot
+	pb.UnimplementedExchangeRateServiceServer
+}
+
+func NewExchangeRateService(db mdb.InitRoot) *ExchangeRateService {
+	return &ExchangeRateService{stg: db}
+}
+
+func (s *ExchangeRateService) SetExchangeRate(ctx context.Context, req *pb.ExchangeRate) (*pb.ExchangeRateResponse, error) {
+	resp, err := s.stg.ExchangeRate().SetExchangeRate(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ExchangeRateService) ListExchangeRates(ctx context.Context, req *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error) {
+	resp, err := s.stg.ExchangeRate().ListExchangeRates(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ExchangeRateService) ConvertAmount(ctx context.Context, req *pb.ConvertAmountRequest) (*pb.ConvertAmountResponse, error) {
+	rate, err := s.stg.ExchangeRate().GetRate(ctx, req.FromCurrency, req.ToCurrency, req.Date)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.ConvertAmountResponse{
+		Amount:   req.Amount * rate,
+		Rate:     rate,
+		Currency: req.ToCurrency,
+	}, nil
+}
+
+// convertAmount converts between currencies at the rate valid on date.
+// Empty currencies are treated as the base currency.
+func convertAmount(ctx context.Context, stg mdb.InitRoot, amount float64, from, to, baseCurrency, date string) (float64, error) {
+	if from == "" {
+		from = baseCurrency
+	}
+	if to == "" {
+		to = baseCurrency
+	}
+	if from == to {
+		return amount, nil
+	}
+	rate, err := stg.ExchangeRate().GetRate(ctx, from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
@@ -9,16 +9,27 @@ import (
 )
 
 type GoalService struct {
-	stg mdb.InitRoot
+	stg      mdb.InitRoot
+	settings *UserSettingsService
 	pb.UnimplementedGoalServiceServer
 }
 
-func NewGoalService(db mdb.InitRoot) *GoalService {
-	return &GoalService{stg: db}
+func NewGoalService(db mdb.InitRoot, settings *UserSettingsService) *GoalService {
+	return &GoalService{stg: db, settings: settings}
 }
 
+// CreateGoal checks the deadline in the user's time zone unless the request names one
 func (s *GoalService) CreateGoal(ctx context.Context, req *pb.CreateGoalRequest) (*pb.Responsee, error) {
-	resp, err := s.stg.Goal().CreateGoal(req)
+	if req.TimeZone == "" {
+		settings, err := s.settings.forUser(ctx, req.UserId)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		req.TimeZone = settings.TimeZone
+	}
+
+	resp, err := s.stg.Goal().CreateGoal(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -27,7 +38,7 @@ func (s *GoalService) CreateGoal(ctx context.Context, req *pb.CreateGoalRequest)
 }
 
 func (s *GoalService) ListGoals(ctx context.Context, req *pb.ListGoalsRequest) (*pb.ListGoalsResponse, error) {
-	resp, err := s.stg.Goal().ListGoals(req)
+	resp, err := s.stg.Goal().ListGoals(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -36,7 +47,7 @@ func (s *GoalService) ListGoals(ctx context.Context, req *pb.ListGoalsRequest) (
 }
 
 func (s *GoalService) GetGoalById(ctx context.Context, req *pb.GetGoalByIdRequest) (*pb.GoalResponse, error) {
-	resp, err := s.stg.Goal().GetGoalById(req)
+	resp, err := s.stg.Goal().GetGoalById(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -45,7 +56,7 @@ func (s *GoalService) GetGoalById(ctx context.Context, req *pb.GetGoalByIdReques
 }
 
 func (s *GoalService) UpdateGoal(ctx context.Context, req *pb.UpdateGoalRequest) (*pb.Responsee, error) {
-	resp, err := s.stg.Goal().UpdateGoal(req)
+	resp, err := s.stg.Goal().UpdateGoal(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -54,10 +65,18 @@ func (s *GoalService) UpdateGoal(ctx context.Context, req *pb.UpdateGoalRequest)
 }
 
 func (s *GoalService) DeleteGoal(ctx context.Context, req *pb.DeleteGoalRequest) (*pb.GoalDeleteResponse, error) {
-	resp, err := s.stg.Goal().DeleteGoal(req)
+	resp, err := s.stg.Goal().DeleteGoal(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 	return resp, nil
 }
+
+func (s *GoalService) RestoreGoal(ctx context.Context, req *pb.RestoreGoalRequest) (*pb.Responsee, error) {
+	if err := s.stg.Goal().RestoreGoal(ctx, req.GoalId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.Responsee{Message: "Goal restored successfully"}, nil
+}
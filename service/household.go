@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+// HouseholdService manages households, their members and what is shared with them.
+// Owners manage the household, editors can change shared items and viewers can only see them.
+type HouseholdService struct {
+	stg mdb.InitRoot
+	pb.UnimplementedHouseholdServiceServer
+}
+
+func NewHouseholdService(db mdb.InitRoot) *HouseholdService {
+	return &HouseholdService{stg: db}
+}
+
+// roleIn returns the user's role in the household, or "" when they aren't a member
+func roleIn(household *pb.Household, userId string) string {
+	for _, m := range household.Members {
+		if m.UserId == userId {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+// checkEditable rejects items shared with, or booked into, a household in which
+// the user may not change shared items. Private items have no householdId.
+func checkEditable(ctx context.Context, stg mdb.InitRoot, householdId, userId string) error {
+	if householdId == "" {
+		return nil
+	}
+	household, err := stg.Household().GetHouseholdById(ctx, householdId)
+	if err != nil {
+		return err
+	}
+	if role := roleIn(household, userId); role != "owner" && role != "editor" {
+		return apperr.FailedPrecondition("household_editor", "user %s can't change items shared with household %s", userId, householdId)
+	}
+	return nil
+}
+
+// checkOwners keeps a household from being left without anyone to manage it
+func checkOwners(household *pb.Household) error {
+	for _, m := range household.Members {
+		if m.Role == "owner" {
+			return nil
+		}
+	}
+	return apperr.FailedPrecondition("household_owner", "household %s must keep at least one owner", household.HouseholdId)
+}
+
+func (s *HouseholdService) CreateHousehold(ctx context.Context, req *pb.CreateHouseholdRequest) (*pb.Household, error) {
+	resp, err := s.stg.Household().CreateHousehold(ctx, req.Name, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *HouseholdService) GetHouseholdById(ctx context.Context, req *pb.GetHouseholdByIdRequest) (*pb.Household, error) {
+	resp, err := s.stg.Household().GetHouseholdById(ctx, req.HouseholdId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *HouseholdService) ListHouseholds(ctx context.Context, req *pb.ListHouseholdsRequest) (*pb.ListHouseholdsResponse, error) {
+	resp, err := s.stg.Household().ListHouseholds(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save stores the changed household and returns it as stored
+func (s *HouseholdService) save(ctx context.Context, household *pb.Household) (*pb.Household, error) {
+	if err := checkOwners(household); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if err := s.stg.Household().UpdateHousehold(ctx, household); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return s.GetHouseholdById(ctx, &pb.GetHouseholdByIdRequest{HouseholdId: household.HouseholdId})
+}
+
+func (s *HouseholdService) UpdateHousehold(ctx context.Context, req *pb.UpdateHouseholdRequest) (*pb.Household, error) {
+	household, err := s.GetHouseholdById(ctx, &pb.GetHouseholdByIdRequest{HouseholdId: req.HouseholdId})
+	if err != nil {
+		return nil, err
+	}
+	household.Name = req.Name
+	household.Version = req.Version
+	return s.save(ctx, household)
+}
+
+func (s *HouseholdService) DeleteHousehold(ctx context.Context, req *pb.DeleteHouseholdRequest) (*pb.HouseholdMessage, error) {
+	if err := s.stg.Household().DeleteHousehold(ctx, req.HouseholdId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.HouseholdMessage{Message: "Household deleted successfully"}, nil
+}
+
+// SetHouseholdMember adds a member or changes their role. Only owners manage members.
+func (s *HouseholdService) SetHouseholdMember(ctx context.Context, req *pb.SetHouseholdMemberRequest) (*pb.Household, error) {
+	household, err := s.GetHouseholdById(ctx, &pb.GetHouseholdByIdRequest{HouseholdId: req.HouseholdId})
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, m := range household.Members {
+		if m.UserId == req.MemberId {
+			m.Role = req.Role
+			found = true
+		}
+	}
+	if !found {
+		household.Members = append(household.Members, &pb.HouseholdMember{UserId: req.MemberId, Role: req.Role})
+	}
+	household.Version = req.Version
+	return s.save(ctx, household)
+}
+
+// RemoveHouseholdMember takes a member out of the household. What they shared stays
+// shared until they make it private again.
+func (s *HouseholdService) RemoveHouseholdMember(ctx context.Context, req *pb.RemoveHouseholdMemberRequest) (*pb.Household, error) {
+	household, err := s.GetHouseholdById(ctx, &pb.GetHouseholdByIdRequest{HouseholdId: req.HouseholdId})
+	if err != nil {
+		return nil, err
+	}
+
+	members := household.Members[:0]
+	for _, m := range household.Members {
+		if m.UserId != req.MemberId {
+			members = append(members, m)
+		}
+	}
+	if len(members) == len(household.Members) {
+		err := apperr.NotFound("household member", req.MemberId)
+		log.Print(err)
+		return nil, err
+	}
+	household.Members = members
+	household.Version = req.Version
+	return s.save(ctx, household)
+}
+
+// ShareAccount shares one of the user's accounts, with its transactions, with a
+// household they can edit, or makes it private again
+func (s *HouseholdService) ShareAccount(ctx context.Context, req *pb.ShareAccountRequest) (*pb.HouseholdMessage, error) {
+	if err := checkEditable(ctx, s.stg, req.HouseholdId, req.UserId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if err := s.stg.Account().SetHousehold(ctx, req.AccountId, req.HouseholdId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.HouseholdMessage{Message: "Account sharing updated successfully"}, nil
+}
+
+// ShareBudget shares one of the user's budgets with a household they can edit, or
+// makes it private again
+func (s *HouseholdService) ShareBudget(ctx context.Context, req *pb.ShareBudgetRequest) (*pb.HouseholdMessage, error) {
+	if err := checkEditable(ctx, s.stg, req.HouseholdId, req.UserId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if err := s.stg.Budget().SetHousehold(ctx, req.BudgetId, req.HouseholdId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.HouseholdMessage{Message: "Budget sharing updated successfully"}, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"budget-service/dates"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+type NetWorthService struct {
+	stg          mdb.InitRoot
+	baseCurrency string
+	settings     *UserSettingsService
+	pb.UnimplementedNetWorthServiceServer
+}
+
+func NewNetWorthService(db mdb.InitRoot, baseCurrency string, settings *UserSettingsService) *NetWorthService {
+	return &NetWorthService{stg: db, baseCurrency: baseCurrency, settings: settings}
+}
+
+func (s *NetWorthService) GetNetWorthHistory(ctx context.Context, req *pb.GetNetWorthHistoryRequest) (*pb.NetWorthHistoryResponse, error) {
+	resp, err := s.stg.NetWorth().GetNetWorthHistory(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TakeSnapshots records today's net worth for every user that has accounts, in
+// the user's own base currency. "Today" is the current day in each user's own
+// time zone. A user whose accounts can't all be converted gets no snapshot that
+// day rather than one that leaves accounts out; the others are still recorded.
+func (s *NetWorthService) TakeSnapshots(ctx context.Context) error {
+	accounts, err := s.stg.Account().ListAccounts(ctx, &pb.ListAccountsRequest{IncludeArchived: true})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	snapshots := make(map[string]*pb.NetWorthSnapshot)
+	failed := make(map[string]error)
+	for _, a := range accounts.Accounts {
+		if failed[a.UserId] != nil {
+			continue
+		}
+		snapshot, ok := snapshots[a.UserId]
+		if !ok {
+			settings, err := s.settings.forUser(ctx, a.UserId)
+			if err != nil {
+				return err
+			}
+			today := now.In(dates.Location(settings.TimeZone)).Format(dates.Layout)
+			snapshot = &pb.NetWorthSnapshot{UserId: a.UserId, Date: today, Currency: settings.BaseCurrency}
+			snapshots[a.UserId] = snapshot
+		}
+
+		// Accounts without a currency are in the server's base currency
+		amount, err := convertAmount(ctx, s.stg, a.Balance, a.Currency, snapshot.Currency, s.baseCurrency, snapshot.Date)
+		if err != nil {
+			failed[a.UserId] = fmt.Errorf("net worth of user %s: account %s: %w", a.UserId, a.AccountId, err)
+			delete(snapshots, a.UserId)
+			continue
+		}
+		if isLiability(a.AccountType) {
+			snapshot.Liabilities += amount
+		} else {
+			snapshot.Assets += amount
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		snapshot.NetWorth = snapshot.Assets - snapshot.Liabilities
+		if err := s.stg.NetWorth().SaveSnapshot(ctx, snapshot); err != nil {
+			return err
+		}
+	}
+	log.Printf("Recorded net worth snapshots for %d users", len(snapshots))
+
+	var errs []error
+	for _, err := range failed {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// RunDaily takes a snapshot right away and then once a day until ctx is cancelled.
+// Snapshots are keyed by day, so a restart on the same day only refreshes them.
+func (s *NetWorthService) RunDaily(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := s.TakeSnapshots(ctx); err != nil {
+			log.Printf("Failed to take net worth snapshots: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
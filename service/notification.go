@@ -18,8 +18,8 @@ func NewNotificationService(stg s.InitRoot) *NotificationService {
 	return &NotificationService{stg: stg}
 }
 
-func (s *NotificationService) CreateNotification(req model.Send) error {
-	err := s.stg.Notification().CreateNotification(req)
+func (s *NotificationService) CreateNotification(ctx context.Context, req model.Send) error {
+	err := s.stg.Notification().CreateNotification(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return err
@@ -30,7 +30,7 @@ func (s *NotificationService) CreateNotification(req model.Send) error {
 
 // GetAccountByid retrieves a notification by user_id
 func (s *NotificationService) GetNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.GetNotificationByidResponse, error) {
-	notification, err := s.stg.Notification().GetNotification(req)
+	notification, err := s.stg.Notification().GetNotification(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
@@ -41,7 +41,7 @@ func (s *NotificationService) GetNotification(ctx context.Context, req *pb.GetNo
 
 // DeleteAccount deletes a notification by user_id
 func (s *NotificationService) DeleteNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.NotificationsResponse, error) {
-	response,err := s.stg.Notification().DeleteNotification(req)
+	response, err := s.stg.Notification().DeleteNotification(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return response, err
@@ -55,12 +55,24 @@ func (s *NotificationService) DeleteNotification(ctx context.Context, req *pb.Ge
 
 // ListAccounts lists all notifications
 func (s *NotificationService) ListNotification(ctx context.Context, req *pb.Void) (*pb.ListNotificationResponse, error) {
-	notifications, err := s.stg.Notification().ListNotification(req)
+	notifications, err := s.stg.Notification().ListNotification(ctx, req)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 
-
 	return notifications, nil
 }
+
+// RestoreNotification takes a deleted notification out of the trash
+func (s *NotificationService) RestoreNotification(ctx context.Context, req *pb.RestoreNotificationRequest) (*pb.NotificationsResponse, error) {
+	if err := s.stg.Notification().RestoreNotification(ctx, req.NotificationId); err != nil {
+		log.Print(err)
+		return &pb.NotificationsResponse{Message: "Failed to restore notification", Success: false}, err
+	}
+
+	return &pb.NotificationsResponse{
+		Message: "Notification restored successfully",
+		Success: true,
+	}, nil
+}
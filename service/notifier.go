@@ -0,0 +1,22 @@
+package service
+
+import (
+	"budget-service/kafka"
+	"budget-service/model"
+)
+
+// Notifier publishes notifications to the Kafka topic the notification consumer
+// stores them from. It shares one producer between all senders.
+type Notifier struct {
+	producer kafka.KafkaProducer
+	topic    string
+}
+
+func NewNotifier(producer kafka.KafkaProducer, topic string) *Notifier {
+	return &Notifier{producer: producer, topic: topic}
+}
+
+// Notify sends message to the user
+func (n *Notifier) Notify(userId, message string) error {
+	return kafka.CreateNotification(n.producer, n.topic, &model.Send{UserId: userId, Message: message})
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"budget-service/dates"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ReconciliationService struct {
+	stg mdb.InitRoot
+	pb.UnimplementedReconciliationServiceServer
+}
+
+func NewReconciliationService(db mdb.InitRoot) *ReconciliationService {
+	return &ReconciliationService{stg: db}
+}
+
+// status compares the statement with the account's cleared balance, which is the
+// current balance without uncleared transactions and anything after the statement date.
+// A transaction belongs to the statement when its day, in its own time zone, is on or
+// before the statement date. It also returns the IDs of the cleared transactions that do.
+func (s *ReconciliationService) status(ctx context.Context, accountId string, statementBalance float64, statementDate string) (*pb.AccountResponse, *pb.ReconciliationStatusResponse, []string, error) {
+	account, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: accountId})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	transactions, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{AccountId: accountId})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cleared := account.Balance
+	var uncleared []*pb.TransactionResponse
+	var clearedIds []string
+	for _, t := range transactions.Transactions {
+		onStatement := dayIn(t.Date, t.TimeZone) <= statementDate
+		if t.Cleared && onStatement {
+			clearedIds = append(clearedIds, t.TransactionId)
+			continue
+		}
+		cleared -= transactionEffect(account.AccountType, t.Type, float64(t.Amount))
+		if !t.Cleared && onStatement {
+			uncleared = append(uncleared, t)
+		}
+	}
+
+	return account, &pb.ReconciliationStatusResponse{
+		AccountId:             accountId,
+		StatementBalance:      statementBalance,
+		StatementDate:         statementDate,
+		ClearedBalance:        roundCents(cleared),
+		Difference:            roundCents(statementBalance - cleared),
+		UnclearedTransactions: uncleared,
+	}, clearedIds, nil
+}
+
+// StartReconciliation shows the uncleared transactions and how far the cleared balance is from the statement
+func (s *ReconciliationService) StartReconciliation(ctx context.Context, req *pb.StartReconciliationRequest) (*pb.ReconciliationStatusResponse, error) {
+	_, resp, _, err := s.status(ctx, req.AccountId, req.StatementBalance, req.StatementDate)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ReconciliationService) MarkTransactionsCleared(ctx context.Context, req *pb.MarkTransactionsClearedRequest) (*pb.MarkTransactionsClearedResponse, error) {
+	updated, err := s.stg.Transaction().SetCleared(ctx, req.AccountId, req.TransactionIds, req.Cleared)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.MarkTransactionsClearedResponse{Updated: updated}, nil
+}
+
+// FinishReconciliation books any remaining difference as a cleared adjustment
+// transaction, locks the cleared transactions and records the reconciliation
+func (s *ReconciliationService) FinishReconciliation(ctx context.Context, req *pb.FinishReconciliationRequest) (*pb.Reconciliation, error) {
+	account, status, clearedIds, err := s.status(ctx, req.AccountId, req.StatementBalance, req.StatementDate)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	rec := &pb.Reconciliation{
+		UserId:           account.UserId,
+		AccountId:        req.AccountId,
+		StatementBalance: req.StatementBalance,
+		StatementDate:    req.StatementDate,
+		ClearedBalance:   status.ClearedBalance,
+		Adjustment:       status.Difference,
+		CreatedAt:        time.Now().Format(time.RFC3339),
+	}
+
+	if status.Difference != 0 {
+		if err := checkOpen(account); err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		statementDay, err := time.Parse(dates.Layout, req.StatementDate)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		adjustment := &pb.CreateTransactionRequest{
+			UserId:           account.UserId,
+			AccountId:        req.AccountId,
+			Amount:           float32(math.Abs(status.Difference)),
+			Type:             adjustmentType(account.AccountType, status.Difference),
+			Description:      "Reconciliation adjustment",
+			Date:             timestamppb.New(statementDay),
+			TimeZone:         "UTC",
+			Currency:         account.Currency,
+			OriginalAmount:   float32(math.Abs(status.Difference)),
+			OriginalCurrency: account.Currency,
+			ExchangeRate:     1,
+			Cleared:          true,
+		}
+
+		if _, err := s.stg.Transaction().CreateTransaction(ctx, adjustment); err != nil {
+			log.Printf("Failed to create reconciliation adjustment: %v", err)
+			return nil, err
+		}
+		if err := applyToBalance(ctx, s.stg, account, adjustment.Type, adjustment.Amount); err != nil {
+			log.Printf("Failed to update account balance: %v", err)
+			return nil, err
+		}
+		rec.AdjustmentTransactionId = adjustment.Id
+		clearedIds = append(clearedIds, adjustment.Id)
+	}
+
+	if err := s.stg.Transaction().MarkReconciled(ctx, req.AccountId, clearedIds); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	if err := s.stg.Reconciliation().CreateReconciliation(ctx, rec); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (s *ReconciliationService) ListReconciliations(ctx context.Context, req *pb.ListReconciliationsRequest) (*pb.ListReconciliationsResponse, error) {
+	resp, err := s.stg.Reconciliation().ListReconciliations(ctx, req)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
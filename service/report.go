@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"budget-service/apperr"
+	"budget-service/dates"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+type ReportService struct {
+	stg          mdb.InitRoot
+	baseCurrency string
+	settings     *UserSettingsService
+	pb.UnimplementedReportServiceServer
+}
+
+func NewReportService(db mdb.InitRoot, baseCurrency string, settings *UserSettingsService) *ReportService {
+	return &ReportService{stg: db, baseCurrency: baseCurrency, settings: settings}
+}
+
+// targetCurrency returns the currency a report was requested in, defaulting to the user's base currency
+func (s *ReportService) targetCurrency(settings *pb.UserSettings, currency string) string {
+	if currency == "" {
+		return settings.BaseCurrency
+	}
+	return currency
+}
+
+// sumTransactions converts every transaction into the target currency at the rate of its own date
+func (s *ReportService) sumTransactions(ctx context.Context, transactions []*pb.TransactionResponse, currency string) (float64, error) {
+	total, _, err := s.bucketTransactions(ctx, transactions, currency, "", nil)
+	return total, err
+}
+
+// bucketTransactions sums the transactions like sumTransactions and, when groupBy
+// names a unit, also per day, week, month or year in the user's time zone.
+// Buckets are returned oldest first.
+func (s *ReportService) bucketTransactions(ctx context.Context, transactions []*pb.TransactionResponse, currency, groupBy string, settings *pb.UserSettings) (float64, []*pb.ReportBucket, error) {
+	var total float64
+	byStart := make(map[string]*pb.ReportBucket)
+	for _, t := range transactions {
+		amount, err := convertAmount(ctx, s.stg, float64(t.Amount), t.Currency, currency, s.baseCurrency, dayIn(t.Date, t.TimeZone))
+		if err != nil {
+			return 0, nil, err
+		}
+		total += amount
+
+		if groupBy == "" {
+			continue
+		}
+		start, ok := periodStart(t.Date.AsTime().In(dates.Location(settings.TimeZone)), groupBy, weekday(settings.WeekStart))
+		if !ok {
+			return 0, nil, apperr.InvalidArgument("group_by", "unknown period %q", groupBy)
+		}
+		key := start.Format(dates.Layout)
+		bucket, ok := byStart[key]
+		if !ok {
+			bucket = &pb.ReportBucket{PeriodStart: key}
+			byStart[key] = bucket
+		}
+		bucket.Amount += amount
+	}
+
+	buckets := make([]*pb.ReportBucket, 0, len(byStart))
+	for _, bucket := range byStart {
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].PeriodStart < buckets[j].PeriodStart })
+	return total, buckets, nil
+}
+
+func (s *ReportService) GetSpendingReport(ctx context.Context, req *pb.GetSpendingReportRequest) (*pb.SpendingReportResponse, error) {
+	settings, err := s.settings.forUser(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	currency := s.targetCurrency(settings, req.Currency)
+
+	resp, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{
+		UserId:    req.UserId,
+		AccountId: req.AccountId,
+		Type:      "-",
+	})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	total, buckets, err := s.bucketTransactions(ctx, resp.Transactions, currency, req.GroupBy, settings)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.SpendingReportResponse{TotalSpent: total, Currency: currency, Buckets: buckets}, nil
+}
+
+func (s *ReportService) GetIncomeReport(ctx context.Context, req *pb.GetIncomeReportRequest) (*pb.IncomeReportResponse, error) {
+	settings, err := s.settings.forUser(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	currency := s.targetCurrency(settings, req.Currency)
+
+	resp, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{
+		UserId:    req.UserId,
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	var income []*pb.TransactionResponse
+	for _, t := range resp.Transactions {
+		if t.Type != "-" && !isTransfer(t.Type) {
+			income = append(income, t)
+		}
+	}
+
+	total, buckets, err := s.bucketTransactions(ctx, income, currency, req.GroupBy, settings)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.IncomeReportResponse{TotalIncome: total, Currency: currency, Buckets: buckets}, nil
+}
+
+// GetBudgetPerformanceReport compares each budget the user can see with its spending,
+// broken down by the member who spent. Personal budgets count the user's spending
+// outside shared accounts; household budgets count every member's spending on the
+// household's shared accounts.
+func (s *ReportService) GetBudgetPerformanceReport(ctx context.Context, req *pb.GetBudgetPerformanceReportRequest) (*pb.BudgetPerformanceReportResponse, error) {
+	settings, err := s.settings.forUser(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	currency := s.targetCurrency(settings, req.Currency)
+
+	budgets, err := s.stg.Budget().ListBudgets(ctx, &pb.ListBudgetsRequest{UserId: req.UserId})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	personal, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{UserId: req.UserId, Type: "-"})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	var private []*pb.TransactionResponse
+	for _, t := range personal.Transactions {
+		if t.HouseholdId == "" {
+			private = append(private, t)
+		}
+	}
+	// Spending by household id, looked up once per household; "" holds the personal spending
+	spendingOf := map[string][]*pb.TransactionResponse{"": private}
+
+	resp := &pb.BudgetPerformanceReportResponse{Currency: currency}
+	for _, b := range budgets.Budgets {
+		amount, err := convertAmount(ctx, s.stg, b.Amount, s.baseCurrency, currency, s.baseCurrency, dayIn(b.StartDate, b.TimeZone))
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+
+		if _, ok := spendingOf[b.HouseholdId]; !ok {
+			shared, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{HouseholdId: b.HouseholdId, Type: "-"})
+			if err != nil {
+				log.Print(err)
+				return nil, err
+			}
+			spendingOf[b.HouseholdId] = shared.Transactions
+		}
+
+		byMember := map[string][]*pb.TransactionResponse{}
+		for _, t := range spendingOf[b.HouseholdId] {
+			if b.CategoryId != "" && t.CategoryId != b.CategoryId {
+				continue
+			}
+			date := t.Date.AsTime()
+			if date.Before(b.StartDate.AsTime()) || date.After(b.EndDate.AsTime()) {
+				continue
+			}
+			byMember[t.UserId] = append(byMember[t.UserId], t)
+		}
+
+		members := make([]string, 0, len(byMember))
+		for userId := range byMember {
+			members = append(members, userId)
+		}
+		sort.Strings(members)
+
+		performance := &pb.BudgetPerformance{
+			BudgetId:    b.BudgetId,
+			HouseholdId: b.HouseholdId,
+			CategoryId:  b.CategoryId,
+			Budget:      amount,
+		}
+		for _, userId := range members {
+			spent, err := s.sumTransactions(ctx, byMember[userId], currency)
+			if err != nil {
+				log.Print(err)
+				return nil, err
+			}
+			performance.Members = append(performance.Members, &pb.MemberSpending{UserId: userId, Spent: spent})
+			performance.Spent += spent
+		}
+
+		resp.Budgets = append(resp.Budgets, performance)
+		resp.TotalBudget += amount
+		resp.TotalSpent += performance.Spent
+	}
+
+	return resp, nil
+}
+
+func (s *ReportService) GetGoalProgressReport(ctx context.Context, req *pb.GetGoalProgressReportRequest) (*pb.GoalProgressReportResponse, error) {
+	settings, err := s.settings.forUser(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	currency := s.targetCurrency(settings, req.Currency)
+
+	goals, err := s.stg.Goal().ListGoals(ctx, &pb.ListGoalsRequest{UserId: req.UserId})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// Goals have no history, so they are converted at today's rate
+	today := time.Now().In(dates.Location(settings.TimeZone)).Format(dates.Layout)
+	var totalGoal, totalSaved float64
+	for _, g := range goals.Goals {
+		target, err := convertAmount(ctx, s.stg, float64(g.TargetAmount), s.baseCurrency, currency, s.baseCurrency, today)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		saved, err := convertAmount(ctx, s.stg, float64(g.CurrentAmount), s.baseCurrency, currency, s.baseCurrency, today)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		totalGoal += target
+		totalSaved += saved
+	}
+
+	return &pb.GoalProgressReportResponse{
+		TotalGoalAmount: totalGoal,
+		TotalSaved:      totalSaved,
+		Currency:        currency,
+	}, nil
+}
@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+
+	"github.com/google/uuid"
+)
+
+// SplitService records expenses paid by one user and shared with others, works out
+// who owes whom and books the settlements paying it back. Debts are kept in the
+// base currency, like budgets and goals.
+type SplitService struct {
+	stg          mdb.InitRoot
+	transactions *TransactionService
+	baseCurrency string
+	pb.UnimplementedSplitServiceServer
+}
+
+func NewSplitService(db mdb.InitRoot, transactions *TransactionService, baseCurrency string) *SplitService {
+	return &SplitService{stg: db, transactions: transactions, baseCurrency: baseCurrency}
+}
+
+// splitShares works out each participant's part of total. Shares without amounts
+// split it equally, the leftover cents going to the first participants; otherwise
+// the amounts must add up to total.
+func splitShares(total float64, shares []*pb.SplitShare) ([]*pb.SplitShare, error) {
+	if len(shares) == 0 {
+		return nil, apperr.InvalidArgument("shares", "at least one share is required")
+	}
+
+	seen := map[string]bool{}
+	given := 0.0
+	for _, share := range shares {
+		if share.UserId == "" {
+			return nil, apperr.InvalidArgument("shares", "every share needs a user_id")
+		}
+		if share.Amount < 0 {
+			return nil, apperr.InvalidArgument("shares", "the share of %s must not be negative", share.UserId)
+		}
+		if seen[share.UserId] {
+			return nil, apperr.InvalidArgument("shares", "user %s has more than one share", share.UserId)
+		}
+		seen[share.UserId] = true
+		given += share.Amount
+	}
+
+	result := make([]*pb.SplitShare, len(shares))
+	if given == 0 {
+		cents := int64(math.Round(total * 100))
+		each, extra := cents/int64(len(shares)), cents%int64(len(shares))
+		for i, share := range shares {
+			c := each
+			if int64(i) < extra {
+				c++
+			}
+			result[i] = &pb.SplitShare{UserId: share.UserId, Amount: float64(c) / 100}
+		}
+		return result, nil
+	}
+
+	if math.Abs(given-total) >= 0.005 {
+		return nil, apperr.InvalidArgument("shares", "shares add up to %.2f instead of %.2f", given, total)
+	}
+	for i, share := range shares {
+		result[i] = &pb.SplitShare{UserId: share.UserId, Amount: roundCents(share.Amount)}
+	}
+	return result, nil
+}
+
+// checkMembers rejects users who aren't members of the household
+func (s *SplitService) checkMembers(ctx context.Context, householdId string, userIds ...string) error {
+	household, err := s.stg.Household().GetHouseholdById(ctx, householdId)
+	if err != nil {
+		return err
+	}
+	for _, userId := range userIds {
+		if roleIn(household, userId) == "" {
+			return apperr.FailedPrecondition("household_member", "user %s is not a member of household %s", userId, householdId)
+		}
+	}
+	return nil
+}
+
+// CreateSplitExpense books the whole expense on the payer's account and records
+// what each other participant owes the payer
+func (s *SplitService) CreateSplitExpense(ctx context.Context, req *pb.CreateSplitExpenseRequest) (*pb.SplitExpense, error) {
+	shares, err := splitShares(req.Amount, req.Shares)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	// Others can only be said to owe the payer within a household they share
+	participants := []string{req.UserId}
+	for _, share := range shares {
+		if share.UserId != req.UserId {
+			participants = append(participants, share.UserId)
+		}
+	}
+	if len(participants) > 1 && req.HouseholdId == "" {
+		err := apperr.InvalidArgument("household_id", "a household is required to split an expense with other users")
+		log.Print(err)
+		return nil, err
+	}
+	if req.HouseholdId != "" {
+		if err := s.checkMembers(ctx, req.HouseholdId, participants...); err != nil {
+			log.Print(err)
+			return nil, err
+		}
+	}
+
+	t := &pb.CreateTransactionRequest{
+		UserId:      req.UserId,
+		AccountId:   req.AccountId,
+		CategoryId:  req.CategoryId,
+		Amount:      float32(req.Amount),
+		Type:        "-",
+		Description: req.Description,
+		Date:        req.Date,
+		TimeZone:    req.TimeZone,
+		Currency:    req.Currency,
+	}
+	if _, err := s.transactions.CreateTransaction(ctx, t); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// The transaction now holds the currency the expense was entered in and its time zone
+	day := dayIn(t.Date, t.TimeZone)
+	split := &pb.SplitExpense{
+		UserId:        req.UserId,
+		TransactionId: t.Id,
+		HouseholdId:   req.HouseholdId,
+		Description:   req.Description,
+		Currency:      s.baseCurrency,
+		Date:          t.Date,
+	}
+	for _, share := range shares {
+		amount, err := convertAmount(ctx, s.stg, share.Amount, t.OriginalCurrency, s.baseCurrency, s.baseCurrency, day)
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+		split.Shares = append(split.Shares, &pb.SplitShare{UserId: share.UserId, Amount: roundCents(amount)})
+	}
+	total, err := convertAmount(ctx, s.stg, req.Amount, t.OriginalCurrency, s.baseCurrency, s.baseCurrency, day)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	split.Amount = roundCents(total)
+
+	if err := s.stg.Split().CreateSplit(ctx, split); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return split, nil
+}
+
+func (s *SplitService) ListSplitExpenses(ctx context.Context, req *pb.ListSplitExpensesRequest) (*pb.ListSplitExpensesResponse, error) {
+	splits, err := s.stg.Split().ListSplits(ctx, req.UserId, req.HouseholdId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.ListSplitExpensesResponse{Splits: splits}, nil
+}
+
+// ious nets the split expenses and settlements involving userId, or everyone when
+// it is empty, within the household if one is given. Records kept in another
+// currency, from before the base currency changed, are converted on their date.
+func (s *SplitService) ious(ctx context.Context, userId, householdId string) ([]*pb.Iou, error) {
+	splits, err := s.stg.Split().ListSplits(ctx, userId, householdId)
+	if err != nil {
+		return nil, err
+	}
+	settlements, err := s.stg.Split().ListSettlements(ctx, userId, householdId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, split := range splits {
+		if split.Currency == "" || split.Currency == s.baseCurrency {
+			continue
+		}
+		day := dayIn(split.Date, "")
+		for _, share := range split.Shares {
+			if share.Amount, err = s.toBase(ctx, share.Amount, split.Currency, day); err != nil {
+				return nil, err
+			}
+		}
+		if split.Amount, err = s.toBase(ctx, split.Amount, split.Currency, day); err != nil {
+			return nil, err
+		}
+		split.Currency = s.baseCurrency
+	}
+	for _, settlement := range settlements {
+		if settlement.Currency == "" || settlement.Currency == s.baseCurrency {
+			continue
+		}
+		if settlement.Amount, err = s.toBase(ctx, settlement.Amount, settlement.Currency, dayIn(settlement.Date, "")); err != nil {
+			return nil, err
+		}
+		settlement.Currency = s.baseCurrency
+	}
+	return pairwise(splits, settlements, s.baseCurrency)
+}
+
+func (s *SplitService) toBase(ctx context.Context, amount float64, currency, day string) (float64, error) {
+	amount, err := convertAmount(ctx, s.stg, amount, currency, s.baseCurrency, s.baseCurrency, day)
+	return roundCents(amount), err
+}
+
+// GetIous lists what the user owes and is owed, one entry per other user
+func (s *SplitService) GetIous(ctx context.Context, req *pb.GetIousRequest) (*pb.IousResponse, error) {
+	all, err := s.ious(ctx, req.UserId, req.HouseholdId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// Splits the user shares in also name what the other participants owe
+	var ious []*pb.Iou
+	for _, iou := range all {
+		if iou.DebtorId == req.UserId || iou.CreditorId == req.UserId {
+			ious = append(ious, iou)
+		}
+	}
+	return &pb.IousResponse{Ious: ious, Currency: s.baseCurrency}, nil
+}
+
+// SimplifyDebts replaces the debts between the household's members with a shorter
+// list of transfers that leaves everyone with the same net balance
+func (s *SplitService) SimplifyDebts(ctx context.Context, req *pb.SimplifyDebtsRequest) (*pb.IousResponse, error) {
+	// Only members may see the household's debts
+	if _, err := s.stg.Household().GetHouseholdById(ctx, req.HouseholdId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	ious, err := s.ious(ctx, "", req.HouseholdId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return &pb.IousResponse{Ious: simplify(ious), Currency: s.baseCurrency}, nil
+}
+
+// RecordSettlement books the user paying back a creditor as a transfer from the
+// user's account into the creditor's, converted from the base currency into each
+// account's currency, and records it against what the user owes. Both must be
+// members of the household, the creditor's account must be shared with it, and
+// the user can't pay back more than they owe the creditor there.
+func (s *SplitService) RecordSettlement(ctx context.Context, req *pb.RecordSettlementRequest) (*pb.Settlement, error) {
+	if err := s.checkMembers(ctx, req.HouseholdId, req.UserId, req.CreditorId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if err := s.checkOwed(ctx, req); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	from, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.FromAccountId})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if err := checkOpen(from); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if err := checkEditable(ctx, s.stg, from.HouseholdId, req.UserId); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// The user reaches the creditor's account only through the household it is shared with
+	to, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.ToAccountId})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if to.UserId != req.CreditorId || to.HouseholdId != req.HouseholdId {
+		err := apperr.FailedPrecondition("creditor_account", "account %s is not an account of %s shared with household %s", to.AccountId, req.CreditorId, req.HouseholdId)
+		log.Print(err)
+		return nil, err
+	}
+	if err := checkOpen(to); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	zone, err := s.transactions.timeZone(ctx, req.UserId, req.TimeZone)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	req.TimeZone = zone
+
+	day := dayIn(req.Date, req.TimeZone)
+	fromAmount, err := convertAmount(ctx, s.stg, req.Amount, s.baseCurrency, from.Currency, s.baseCurrency, day)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	toAmount, err := convertAmount(ctx, s.stg, req.Amount, s.baseCurrency, to.Currency, s.baseCurrency, day)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	if isLiability(from.AccountType) && from.CreditLimit > 0 && from.Balance+fromAmount > from.CreditLimit {
+		err = apperr.FailedPrecondition("credit_limit", "amount %.2f exceeds the available credit of %.2f", fromAmount, from.AvailableCredit)
+		log.Print(err)
+		return nil, err
+	}
+
+	transferId := uuid.NewString()
+	legs := []struct {
+		account *pb.AccountResponse
+		req     *pb.CreateTransactionRequest
+	}{
+		{from, &pb.CreateTransactionRequest{
+			UserId:           req.UserId,
+			AccountId:        from.AccountId,
+			Amount:           float32(fromAmount),
+			Type:             transferOut,
+			Description:      req.Description,
+			Date:             req.Date,
+			TimeZone:         req.TimeZone,
+			Currency:         from.Currency,
+			OriginalAmount:   float32(req.Amount),
+			OriginalCurrency: s.baseCurrency,
+			ExchangeRate:     fromAmount / req.Amount,
+			TransferId:       transferId,
+			HouseholdId:      from.HouseholdId,
+		}},
+		{to, &pb.CreateTransactionRequest{
+			UserId:           req.CreditorId,
+			AccountId:        to.AccountId,
+			Amount:           float32(toAmount),
+			Type:             transferIn,
+			Description:      req.Description,
+			Date:             req.Date,
+			TimeZone:         req.TimeZone,
+			Currency:         to.Currency,
+			OriginalAmount:   float32(req.Amount),
+			OriginalCurrency: s.baseCurrency,
+			ExchangeRate:     toAmount / req.Amount,
+			TransferId:       transferId,
+			HouseholdId:      to.HouseholdId,
+		}},
+	}
+	for _, leg := range legs {
+		if err := bookTransferLeg(ctx, s.stg, leg.account, leg.req); err != nil {
+			log.Print(err)
+			return nil, err
+		}
+	}
+
+	settlement := &pb.Settlement{
+		DebtorId:    req.UserId,
+		CreditorId:  req.CreditorId,
+		Amount:      roundCents(req.Amount),
+		Currency:    s.baseCurrency,
+		HouseholdId: req.HouseholdId,
+		TransferId:  transferId,
+		Date:        req.Date,
+	}
+	if err := s.stg.Split().CreateSettlement(ctx, settlement); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// checkOwed rejects a settlement for more than the user owes the creditor in the household
+func (s *SplitService) checkOwed(ctx context.Context, req *pb.RecordSettlementRequest) error {
+	ious, err := s.ious(ctx, req.UserId, req.HouseholdId)
+	if err != nil {
+		return err
+	}
+	owed := 0.0
+	for _, iou := range ious {
+		if iou.DebtorId == req.UserId && iou.CreditorId == req.CreditorId {
+			owed = iou.Amount
+		}
+	}
+	if roundCents(req.Amount) > owed {
+		return apperr.FailedPrecondition("outstanding_debt", "user %s owes %s %.2f in household %s, less than %.2f", req.UserId, req.CreditorId, owed, req.HouseholdId, req.Amount)
+	}
+	return nil
+}
+
+// pairwise nets what each pair of users owes the other. Every share of a split is
+// owed to its payer; a settlement pays the creditor back. All records must be in
+// currency, or have none.
+func pairwise(splits []*pb.SplitExpense, settlements []*pb.Settlement, currency string) ([]*pb.Iou, error) {
+	// keyed by the pair in sorted order, positive when the first owes the second
+	owed := map[[2]string]float64{}
+	add := func(debtor, creditor string, amount float64) {
+		switch {
+		case debtor < creditor:
+			owed[[2]string{debtor, creditor}] += amount
+		case debtor > creditor:
+			owed[[2]string{creditor, debtor}] -= amount
+		}
+	}
+	for _, split := range splits {
+		if split.Currency != "" && split.Currency != currency {
+			return nil, apperr.FailedPrecondition("currency", "split %s is in %s, not %s", split.SplitId, split.Currency, currency)
+		}
+		for _, share := range split.Shares {
+			add(share.UserId, split.UserId, share.Amount)
+		}
+	}
+	for _, settlement := range settlements {
+		if settlement.Currency != "" && settlement.Currency != currency {
+			return nil, apperr.FailedPrecondition("currency", "settlement %s is in %s, not %s", settlement.SettlementId, settlement.Currency, currency)
+		}
+		add(settlement.CreditorId, settlement.DebtorId, settlement.Amount)
+	}
+
+	var ious []*pb.Iou
+	for pair, amount := range owed {
+		amount = roundCents(amount)
+		if amount > 0 {
+			ious = append(ious, &pb.Iou{DebtorId: pair[0], CreditorId: pair[1], Amount: amount})
+		} else if amount < 0 {
+			ious = append(ious, &pb.Iou{DebtorId: pair[1], CreditorId: pair[0], Amount: -amount})
+		}
+	}
+	sort.Slice(ious, func(i, j int) bool {
+		if ious[i].DebtorId != ious[j].DebtorId {
+			return ious[i].DebtorId < ious[j].DebtorId
+		}
+		return ious[i].CreditorId < ious[j].CreditorId
+	})
+	return ious, nil
+}
+
+// simplify settles the net balance of everyone in ious by having the biggest debtor
+// pay the biggest creditor until all balances are zero. This takes at most one
+// transfer fewer than there are users with a balance.
+func simplify(ious []*pb.Iou) []*pb.Iou {
+	// Balances are kept in cents so they reach exactly zero
+	balance := map[string]int64{}
+	for _, iou := range ious {
+		cents := int64(math.Round(iou.Amount * 100))
+		balance[iou.DebtorId] -= cents
+		balance[iou.CreditorId] += cents
+	}
+
+	type party struct {
+		userId string
+		cents  int64
+	}
+	var debtors, creditors []party
+	for userId, cents := range balance {
+		if cents < 0 {
+			debtors = append(debtors, party{userId, -cents})
+		} else if cents > 0 {
+			creditors = append(creditors, party{userId, cents})
+		}
+	}
+	for _, parties := range [][]party{debtors, creditors} {
+		sort.Slice(parties, func(i, j int) bool {
+			if parties[i].cents != parties[j].cents {
+				return parties[i].cents > parties[j].cents
+			}
+			return parties[i].userId < parties[j].userId
+		})
+	}
+
+	var transfers []*pb.Iou
+	for i, j := 0, 0; i < len(debtors) && j < len(creditors); {
+		pay := min(debtors[i].cents, creditors[j].cents)
+		transfers = append(transfers, &pb.Iou{
+			DebtorId:   debtors[i].userId,
+			CreditorId: creditors[j].userId,
+			Amount:     float64(pay) / 100,
+		})
+		debtors[i].cents -= pay
+		creditors[j].cents -= pay
+		if debtors[i].cents == 0 {
+			i++
+		}
+		if creditors[j].cents == 0 {
+			j++
+		}
+	}
+	return transfers
+}
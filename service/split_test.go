@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func iou(debtor, creditor string, amount float64) *pb.Iou {
+	return &pb.Iou{DebtorId: debtor, CreditorId: creditor, Amount: amount}
+}
+
+func equalIous(got, want []*pb.Iou) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !proto.Equal(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// split is an expense of the given currency paid by payer; shares alternate user ids and amounts
+func split(payer, currency string, shares ...interface{}) *pb.SplitExpense {
+	s := &pb.SplitExpense{SplitId: payer + "-split", UserId: payer, Currency: currency}
+	for i := 0; i < len(shares); i += 2 {
+		share := &pb.SplitShare{UserId: shares[i].(string), Amount: shares[i+1].(float64)}
+		s.Shares = append(s.Shares, share)
+		s.Amount += share.Amount
+	}
+	return s
+}
+
+func settlement(debtor, creditor, currency string, amount float64) *pb.Settlement {
+	return &pb.Settlement{SettlementId: debtor + "-pays", DebtorId: debtor, CreditorId: creditor, Currency: currency, Amount: amount}
+}
+
+func TestPairwise(t *testing.T) {
+	tests := []struct {
+		name        string
+		splits      []*pb.SplitExpense
+		settlements []*pb.Settlement
+		want        []*pb.Iou
+	}{
+		{
+			name:   "payer's own share isn't owed",
+			splits: []*pb.SplitExpense{split("ann", "UZS", "ann", 50.0, "bob", 50.0)},
+			want:   []*pb.Iou{iou("bob", "ann", 50)},
+		},
+		{
+			name: "opposite debts net out",
+			splits: []*pb.SplitExpense{
+				split("ann", "UZS", "bob", 30.0),
+				split("bob", "UZS", "ann", 10.0),
+			},
+			want: []*pb.Iou{iou("bob", "ann", 20)},
+		},
+		{
+			name: "exact netting leaves nothing",
+			splits: []*pb.SplitExpense{
+				split("ann", "UZS", "bob", 12.34),
+				split("bob", "UZS", "ann", 12.34),
+			},
+		},
+		{
+			name: "a cycle stays pairwise",
+			splits: []*pb.SplitExpense{
+				split("ann", "UZS", "bob", 10.0),
+				split("bob", "UZS", "cat", 10.0),
+				split("cat", "UZS", "ann", 10.0),
+			},
+			want: []*pb.Iou{iou("ann", "cat", 10), iou("bob", "ann", 10), iou("cat", "bob", 10)},
+		},
+		{
+			name:        "partial settlement",
+			splits:      []*pb.SplitExpense{split("ann", "UZS", "bob", 50.0)},
+			settlements: []*pb.Settlement{settlement("bob", "ann", "UZS", 20)},
+			want:        []*pb.Iou{iou("bob", "ann", 30)},
+		},
+		{
+			name:        "full settlement",
+			splits:      []*pb.SplitExpense{split("ann", "UZS", "bob", 50.0)},
+			settlements: []*pb.Settlement{settlement("bob", "ann", "UZS", 30), settlement("bob", "ann", "UZS", 20)},
+		},
+		{
+			name:        "overpaying turns the debt around",
+			splits:      []*pb.SplitExpense{split("ann", "UZS", "bob", 50.0)},
+			settlements: []*pb.Settlement{settlement("bob", "ann", "UZS", 60)},
+			want:        []*pb.Iou{iou("ann", "bob", 10)},
+		},
+		{
+			name:        "records without a currency count as the base currency",
+			splits:      []*pb.SplitExpense{split("ann", "", "bob", 50.0)},
+			settlements: []*pb.Settlement{settlement("bob", "ann", "UZS", 50)},
+		},
+		{
+			name:   "float noise is rounded away",
+			splits: []*pb.SplitExpense{split("ann", "UZS", "bob", 0.1), split("ann", "UZS", "bob", 0.2)},
+			want:   []*pb.Iou{iou("bob", "ann", 0.3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pairwise(tt.splits, tt.settlements, "UZS")
+			if err != nil {
+				t.Fatalf("pairwise: %v", err)
+			}
+			if !equalIous(got, tt.want) {
+				t.Errorf("pairwise = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPairwiseRejectsOtherCurrencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		splits      []*pb.SplitExpense
+		settlements []*pb.Settlement
+	}{
+		{name: "split", splits: []*pb.SplitExpense{split("ann", "USD", "bob", 50.0)}},
+		{name: "settlement", settlements: []*pb.Settlement{settlement("bob", "ann", "USD", 50)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pairwise(tt.splits, tt.settlements, "UZS")
+			if e, ok := apperr.As(err); !ok || e.Subject != "currency" {
+				t.Fatalf("pairwise error = %v, want a currency precondition", err)
+			}
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		name string
+		ious []*pb.Iou
+		want []*pb.Iou
+	}{
+		{name: "nothing owed"},
+		{
+			name: "a cycle cancels out",
+			ious: []*pb.Iou{iou("ann", "bob", 10), iou("bob", "cat", 10), iou("cat", "ann", 10)},
+		},
+		{
+			name: "an uneven cycle leaves the difference",
+			ious: []*pb.Iou{iou("ann", "bob", 30), iou("bob", "cat", 10), iou("cat", "ann", 10)},
+			want: []*pb.Iou{iou("ann", "bob", 20)},
+		},
+		{
+			name: "a chain is paid directly",
+			ious: []*pb.Iou{iou("ann", "bob", 25), iou("bob", "cat", 25)},
+			want: []*pb.Iou{iou("ann", "cat", 25)},
+		},
+		{
+			name: "biggest debtor pays biggest creditor first",
+			ious: []*pb.Iou{iou("ann", "cat", 40), iou("bob", "cat", 10), iou("bob", "dan", 30)},
+			want: []*pb.Iou{iou("ann", "cat", 40), iou("bob", "cat", 10), iou("bob", "dan", 30)},
+		},
+		{
+			name: "cents add up exactly",
+			ious: []*pb.Iou{iou("ann", "bob", 0.1), iou("ann", "bob", 0.2), iou("bob", "cat", 0.3)},
+			want: []*pb.Iou{iou("ann", "cat", 0.3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := simplify(tt.ious)
+			if !equalIous(got, tt.want) {
+				t.Errorf("simplify = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// splitStorage serves the households, accounts and splits RecordSettlement checks.
+// Any other storage call panics on the nil interfaces, so a rejected settlement
+// is known not to have booked anything.
+type splitStorage struct {
+	mdb.InitRoot
+	households  map[string]*pb.Household
+	accounts    map[string]*pb.AccountResponse
+	splits      []*pb.SplitExpense
+	settlements []*pb.Settlement
+}
+
+func (s *splitStorage) Household() mdb.HouseholdStorage { return fakeHouseholds{s: s} }
+func (s *splitStorage) Account() mdb.AccountStorage     { return fakeAccounts{s: s} }
+func (s *splitStorage) Split() mdb.SplitStorage         { return fakeSplits{s: s} }
+
+type fakeHouseholds struct {
+	mdb.HouseholdStorage
+	s *splitStorage
+}
+
+func (f fakeHouseholds) GetHouseholdById(ctx context.Context, householdID string) (*pb.Household, error) {
+	if h, ok := f.s.households[householdID]; ok {
+		return h, nil
+	}
+	return nil, apperr.NotFound("household", householdID)
+}
+
+type fakeAccounts struct {
+	mdb.AccountStorage
+	s *splitStorage
+}
+
+func (f fakeAccounts) GetAccountById(ctx context.Context, req *pb.GetAccountByIdRequest) (*pb.AccountResponse, error) {
+	if a, ok := f.s.accounts[req.AccountId]; ok {
+		return a, nil
+	}
+	return nil, apperr.NotFound("account", req.AccountId)
+}
+
+type fakeSplits struct {
+	mdb.SplitStorage
+	s *splitStorage
+}
+
+func (f fakeSplits) ListSplits(ctx context.Context, userID, householdID string) ([]*pb.SplitExpense, error) {
+	return f.s.splits, nil
+}
+
+func (f fakeSplits) ListSettlements(ctx context.Context, userID, householdID string) ([]*pb.Settlement, error) {
+	return f.s.settlements, nil
+}
+
+func TestRecordSettlementRejects(t *testing.T) {
+	stg := &splitStorage{
+		households: map[string]*pb.Household{
+			"home": {HouseholdId: "home", Members: []*pb.HouseholdMember{
+				{UserId: "ann", Role: "owner"}, {UserId: "bob", Role: "editor"},
+			}},
+		},
+		accounts: map[string]*pb.AccountResponse{
+			"ann-private":  {AccountId: "ann-private", UserId: "ann", AccountType: "checking", Currency: "UZS"},
+			"bob-wallet":   {AccountId: "bob-wallet", UserId: "bob", AccountType: "cash", Currency: "UZS"},
+			"bob-shared":   {AccountId: "bob-shared", UserId: "bob", AccountType: "cash", Currency: "UZS", HouseholdId: "home"},
+			"cat-checking": {AccountId: "cat-checking", UserId: "cat", AccountType: "checking", Currency: "UZS"},
+			"cat-shared":   {AccountId: "cat-shared", UserId: "cat", AccountType: "checking", Currency: "UZS", HouseholdId: "home"},
+		},
+		// bob owes ann 40
+		splits: []*pb.SplitExpense{split("ann", "UZS", "ann", 40.0, "bob", 40.0)},
+	}
+	s := NewSplitService(stg, nil, "UZS")
+
+	tests := []struct {
+		name string
+		req  *pb.RecordSettlementRequest
+		rule string
+	}{
+		{
+			name: "a stranger's account",
+			req:  &pb.RecordSettlementRequest{UserId: "bob", CreditorId: "cat", Amount: 10, FromAccountId: "bob-wallet", ToAccountId: "cat-checking", HouseholdId: "home"},
+			rule: "household_member",
+		},
+		{
+			name: "without a household",
+			req:  &pb.RecordSettlementRequest{UserId: "bob", CreditorId: "cat", Amount: 10, FromAccountId: "bob-wallet", ToAccountId: "cat-checking"},
+		},
+		{
+			name: "a creditor the user owes nothing",
+			req:  &pb.RecordSettlementRequest{UserId: "ann", CreditorId: "bob", Amount: 10, FromAccountId: "cat-shared", ToAccountId: "bob-shared", HouseholdId: "home"},
+			rule: "outstanding_debt",
+		},
+		{
+			name: "more than is owed",
+			req:  &pb.RecordSettlementRequest{UserId: "bob", CreditorId: "ann", Amount: 40.01, FromAccountId: "bob-wallet", ToAccountId: "cat-shared", HouseholdId: "home"},
+			rule: "outstanding_debt",
+		},
+		{
+			name: "the creditor's account not shared with the household",
+			req:  &pb.RecordSettlementRequest{UserId: "bob", CreditorId: "ann", Amount: 40, FromAccountId: "bob-wallet", ToAccountId: "ann-private", HouseholdId: "home"},
+			rule: "creditor_account",
+		},
+		{
+			name: "a household account of someone else than the creditor",
+			req:  &pb.RecordSettlementRequest{UserId: "bob", CreditorId: "ann", Amount: 40, FromAccountId: "bob-wallet", ToAccountId: "cat-shared", HouseholdId: "home"},
+			rule: "creditor_account",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.RecordSettlement(context.Background(), tt.req)
+			if err == nil {
+				t.Fatal("settlement was recorded")
+			}
+			if tt.rule == "" {
+				return
+			}
+			if e, ok := apperr.As(err); !ok || e.Kind != apperr.KindFailedPrecondition || e.Subject != tt.rule {
+				t.Fatalf("error = %v, want the %s precondition", err, tt.rule)
+			}
+		})
+	}
+}
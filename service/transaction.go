@@ -2,80 +2,156 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"log"
 
+	"budget-service/apperr"
 	pb "budget-service/genproto"
-	"budget-service/kafka"
-	"budget-service/model"
 	mdb "budget-service/storage"
+
+	"github.com/google/uuid"
 )
 
-func ConnectToKafka() kafka.KafkaProducer {
-	kaf, err := kafka.NewKafkaProducer([]string{"localhost:9092"})
-	if err != nil {
-		log.Fatal("Error while connection kafka: ", err.Error())
-	}
-	return kaf
+type TransactionService struct {
+	stg          mdb.InitRoot
+	baseCurrency string
+	settings     *UserSettingsService
+	notifier     *Notifier
+	pb.UnimplementedTransactionServiceServer
+}
 
+func NewTransactionService(db mdb.InitRoot, baseCurrency string, settings *UserSettingsService, notifier *Notifier) *TransactionService {
+	return &TransactionService{stg: db, baseCurrency: baseCurrency, settings: settings, notifier: notifier}
 }
 
-type TransactionService struct {
-	stg mdb.InitRoot
-	pb.UnimplementedTransactionServiceServer
+// timeZone returns zone, or the user's time zone when the request didn't name one
+func (s *TransactionService) timeZone(ctx context.Context, userId, zone string) (string, error) {
+	if zone != "" {
+		return zone, nil
+	}
+	settings, err := s.settings.forUser(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	return settings.TimeZone, nil
 }
 
-func NewTransactionService(db mdb.InitRoot) *TransactionService {
-	return &TransactionService{stg: db}
+// convertToAccountCurrency stores the amount in the account's currency and keeps
+// the amount the user entered, with the rate used, for history
+func (s *TransactionService) convertToAccountCurrency(ctx context.Context, req *pb.CreateTransactionRequest, accountCurrency string) error {
+	if accountCurrency == "" {
+		accountCurrency = s.baseCurrency
+	}
+	if req.Currency == "" {
+		req.Currency = accountCurrency
+	}
+
+	rate := 1.0
+	if req.Currency != accountCurrency {
+		var err error
+		rate, err = s.stg.ExchangeRate().GetRate(ctx, req.Currency, accountCurrency, dayIn(req.Date, req.TimeZone))
+		if err != nil {
+			return err
+		}
+	}
+
+	req.OriginalAmount = req.Amount
+	req.OriginalCurrency = req.Currency
+	req.ExchangeRate = rate
+	req.Amount = float32(float64(req.Amount) * rate)
+	req.Currency = accountCurrency
+	return nil
 }
 
 func (s *TransactionService) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.Response, error) {
-	// Create the transaction
-	resp, err := s.stg.Transaction().CreateTransaction(req)
+	zone, err := s.timeZone(ctx, req.UserId, req.TimeZone)
 	if err != nil {
+		log.Printf("Failed to get user settings: %v", err)
+		return &pb.Response{Message: "Failed to get user settings"}, err
+	}
+	req.TimeZone = zone
+
+	account, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.AccountId})
+	if err != nil {
+		log.Printf("Failed to get account: %v", err)
+		return &pb.Response{Message: "Failed to get account"}, err
+	}
+	if err := checkOpen(account); err != nil {
 		log.Printf("Failed to create transaction: %v", err)
-		return &pb.Response{Message: "Failed to create transaction"}, err
+		return &pb.Response{Message: "Account is archived"}, err
+	}
+	// Transactions on a shared account are shared with its household
+	if err := checkEditable(ctx, s.stg, account.HouseholdId, req.UserId); err != nil {
+		log.Printf("Failed to create transaction: %v", err)
+		return &pb.Response{Message: "Account is shared read-only"}, err
+	}
+	req.HouseholdId = account.HouseholdId
+
+	if err := s.convertToAccountCurrency(ctx, req, account.Currency); err != nil {
+		log.Printf("Failed to convert transaction amount: %v", err)
+		return &pb.Response{Message: "Failed to convert transaction amount"}, err
 	}
 
-	// Handle balance updates and notifications
+	// Budgets and goals are kept in the base currency
+	baseAmount, err := convertAmount(ctx, s.stg, float64(req.Amount), req.Currency, s.baseCurrency, s.baseCurrency, dayIn(req.Date, req.TimeZone))
+	if err != nil {
+		log.Printf("Failed to convert transaction amount: %v", err)
+		return &pb.Response{Message: "Failed to convert transaction amount"}, err
+	}
+
+	// Spending on a credit card or loan must stay within its credit limit
+	if isLiability(account.AccountType) && isOutflow(req.Type) && account.CreditLimit > 0 &&
+		account.Balance+float64(req.Amount) > account.CreditLimit {
+		err = apperr.FailedPrecondition("credit_limit", "amount %.2f exceeds the available credit of %.2f", req.Amount, account.AvailableCredit)
+		log.Printf("Failed to create transaction: %v", err)
+		return &pb.Response{Message: "Credit limit exceeded"}, err
+	}
+
+	// Check the budget before anything is written, so a failed check leaves no
+	// half-booked transaction behind
+	budgetLeft := true
 	if req.Type == "-" {
-		// Withdraw: Update account balance and budget amount
-		err = s.stg.Account().UpdateBalanceMinus(ctx, req.AccountId, req.Amount)
+		budgetLeft, err = s.stg.Budget().CheckBudget(ctx, req.UserId, float32(baseAmount))
 		if err != nil {
-			log.Printf("Failed to update account balance: %v", err)
-			return &pb.Response{Message: "Failed to update account balance"}, err
+			log.Printf("Failed to check budget: %v", err)
+			return &pb.Response{Message: "Failed to check budget"}, err
 		}
+	}
+
+	// Create the transaction
+	resp, err := s.stg.Transaction().CreateTransaction(ctx, req)
+	if err != nil {
+		log.Printf("Failed to create transaction: %v", err)
+		return &pb.Response{Message: "Failed to create transaction"}, err
+	}
 
-		err = s.stg.Budget().UpdateBudgetAmount(req.UserId, req.Amount)
+	err = applyToBalance(ctx, s.stg, account, req.Type, req.Amount)
+	if err != nil {
+		log.Printf("Failed to update account balance: %v", err)
+		return &pb.Response{Message: "Failed to update account balance"}, err
+	}
+
+	// Handle budget and goal updates and notifications
+	if isTransfer(req.Type) {
+		// Transfers move money between accounts the user can book into
+		return resp, nil
+	}
+	if req.Type == "-" {
+		// Withdraw: Update budget amount
+		err = s.stg.Budget().UpdateBudgetAmount(ctx, req.UserId, float32(baseAmount))
 		if err != nil {
 			log.Printf("Failed to update budget amount: %v", err)
 			return &pb.Response{Message: "Failed to update budget amount"}, err
 		}
-
-		check, err := s.stg.Budget().CheckBudget(req.UserId)
-		if err != nil {
-			log.Printf("Failed to check budget: %v", err)
-			return &pb.Response{Message: "Failed to check budget"}, err
-		}
-		if !check {
-			kafkaConn := ConnectToKafka()
-			fmt.Println("Sending Kafka notification")
-			request := model.Send{Message: "Your Budget is depleted", UserId: req.UserId}
-			err = kafka.CreateNotification(kafkaConn, &request)
+		if !budgetLeft {
+			err = s.notifier.Notify(req.UserId, "Your Budget is depleted")
 			if err != nil {
 				log.Printf("Failed to send Kafka notification: %v", err)
 				return &pb.Response{Message: "Failed to send notification"}, err
 			}
 		}
 	} else {
-		// Deposit: Update account balance and goal amount
-		err = s.stg.Account().UpdateBalance(ctx, req.AccountId, req.Amount)
-		if err != nil {
-			log.Printf("Failed to update account balance: %v", err)
-			return &pb.Response{Message: "Failed to update account balance"}, err
-		}
-
-		err = s.stg.Goal().UpdateGoalAmount(ctx, req.UserId, req.Amount)
+		// Deposit: Update goal amount
+		err = s.stg.Goal().UpdateGoalAmount(ctx, req.UserId, float32(baseAmount))
 		if err != nil {
 			log.Printf("Failed to update goal amount: %v", err)
 			return &pb.Response{Message: "Failed to update goal amount"}, err
@@ -87,9 +163,7 @@ func (s *TransactionService) CreateTransaction(ctx context.Context, req *pb.Crea
 			return &pb.Response{Message: "Failed to check goal"}, err
 		}
 		if !goalCheck {
-			kafkaConn := ConnectToKafka()
-			request := model.Send{Message: message, UserId: req.UserId}
-			err = kafka.CreateNotification(kafkaConn, &request)
+			err = s.notifier.Notify(req.UserId, message)
 			if err != nil {
 				log.Printf("Failed to send Kafka notification: %v", err)
 				return &pb.Response{Message: "Failed to send notification"}, err
@@ -101,7 +175,7 @@ func (s *TransactionService) CreateTransaction(ctx context.Context, req *pb.Crea
 }
 
 func (s *TransactionService) GetTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (*pb.TransactionsResponse, error) {
-	resp, err := s.stg.Transaction().GetTransactions(req)
+	resp, err := s.stg.Transaction().GetTransactions(ctx, req)
 	if err != nil {
 		log.Printf("Failed to get transactions: %v", err)
 		return nil, err
@@ -110,7 +184,7 @@ func (s *TransactionService) GetTransactions(ctx context.Context, req *pb.GetTra
 }
 
 func (s *TransactionService) GetTransactionById(ctx context.Context, req *pb.GetTransactionByIdRequest) (*pb.TransactionResponse, error) {
-	resp, err := s.stg.Transaction().GetTransactionById(req)
+	resp, err := s.stg.Transaction().GetTransactionById(ctx, req)
 	if err != nil {
 		log.Printf("Failed to get transaction by ID: %v", err)
 		return nil, err
@@ -118,20 +192,299 @@ func (s *TransactionService) GetTransactionById(ctx context.Context, req *pb.Get
 	return resp, nil
 }
 
+// UpdateTransaction changes a transaction and, when the amount, type, account or
+// date change, takes the old version off the balances and books the new one.
+// Reconciled transactions can't be changed, and neither can the booking of one
+// side of a transfer, since the other side would no longer match.
 func (s *TransactionService) UpdateTransaction(ctx context.Context, req *pb.UpdateTransactionRequest) (*pb.Response, error) {
-	resp, err := s.stg.Transaction().UpdateTransaction(req)
+	before, err := s.stg.Transaction().GetTransactionById(ctx, &pb.GetTransactionByIdRequest{TransactionId: req.TransactionId})
 	if err != nil {
 		log.Printf("Failed to update transaction: %v", err)
 		return &pb.Response{Message: "Failed to update transaction"}, err
 	}
+	if err := s.checkUpdate(ctx, before, req); err != nil {
+		log.Printf("Failed to update transaction: %v", err)
+		return &pb.Response{Message: "Failed to update transaction"}, err
+	}
+
+	resp, err := s.stg.Transaction().UpdateTransaction(ctx, req)
+	if err != nil {
+		log.Printf("Failed to update transaction: %v", err)
+		return &pb.Response{Message: "Failed to update transaction"}, err
+	}
+	if !rebooks(before, req) {
+		return resp, nil
+	}
+
+	after, err := s.stg.Transaction().GetTransactionById(ctx, &pb.GetTransactionByIdRequest{TransactionId: req.TransactionId})
+	if err != nil {
+		log.Printf("Failed to update balances for updated transaction: %v", err)
+		return &pb.Response{Message: "Failed to update account balance"}, err
+	}
+	if err := s.book(ctx, before, true); err != nil {
+		log.Printf("Failed to update balances for updated transaction: %v", err)
+		return &pb.Response{Message: "Failed to update account balance"}, err
+	}
+	if err := s.book(ctx, after, false); err != nil {
+		log.Printf("Failed to update balances for updated transaction: %v", err)
+		return &pb.Response{Message: "Failed to update account balance"}, err
+	}
 	return resp, nil
 }
 
+// checkUpdate rejects changes the balances can't follow
+func (s *TransactionService) checkUpdate(ctx context.Context, t *pb.TransactionResponse, req *pb.UpdateTransactionRequest) error {
+	if t.Reconciled {
+		return apperr.FailedPrecondition("not_reconciled", "transaction %s is reconciled and can't be changed", t.TransactionId)
+	}
+	if !rebooks(t, req) {
+		return nil
+	}
+	if t.TransferId != "" {
+		return apperr.FailedPrecondition("transfer_leg", "transaction %s is part of transfer %s; delete and recreate the transfer to change its amount, type, account or date", t.TransactionId, t.TransferId)
+	}
+	if req.AccountId == "" || req.AccountId == t.AccountId {
+		return nil
+	}
+
+	account, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.AccountId})
+	if err != nil {
+		return err
+	}
+	if err := checkOpen(account); err != nil {
+		return err
+	}
+	if err := checkEditable(ctx, s.stg, account.HouseholdId, t.UserId); err != nil {
+		return err
+	}
+	// The stored amount is in the account's currency
+	currency := account.Currency
+	if currency == "" {
+		currency = s.baseCurrency
+	}
+	if currency != t.Currency {
+		return apperr.FailedPrecondition("account_currency", "account %s is in %s, not %s", account.AccountId, currency, t.Currency)
+	}
+	return nil
+}
+
+// rebooks reports whether an update changes how a transaction is booked
+func rebooks(t *pb.TransactionResponse, req *pb.UpdateTransactionRequest) bool {
+	return (req.AccountId != "" && req.AccountId != t.AccountId) ||
+		(req.Amount > 0 && req.Amount != t.Amount) ||
+		(req.Type != "" && req.Type != t.Type) ||
+		(req.Date != nil && !req.Date.AsTime().Equal(t.Date.AsTime())) ||
+		(req.TimeZone != "" && req.TimeZone != t.TimeZone)
+}
+
+// transferLegs returns the transaction, or both sides of it when it is part of a transfer
+func (s *TransactionService) transferLegs(ctx context.Context, transactionId string) ([]*pb.TransactionResponse, error) {
+	t, err := s.stg.Transaction().GetTransactionById(ctx, &pb.GetTransactionByIdRequest{TransactionId: transactionId})
+	if err != nil {
+		return nil, err
+	}
+	if t.TransferId == "" {
+		return []*pb.TransactionResponse{t}, nil
+	}
+	legs, err := s.stg.Transaction().GetTransactions(ctx, &pb.GetTransactionsRequest{UserId: t.UserId, TransferId: t.TransferId})
+	if err != nil {
+		return nil, err
+	}
+	return legs.Transactions, nil
+}
+
+// book adds a stored transaction's effects back, or takes them off when undo is set:
+// the account balance and the running budget or goal amount
+func (s *TransactionService) book(ctx context.Context, t *pb.TransactionResponse, undo bool) error {
+	account, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: t.AccountId})
+	if err != nil {
+		return err
+	}
+	if undo {
+		err = revertFromBalance(ctx, s.stg, account, t.Type, t.Amount)
+	} else {
+		err = applyToBalance(ctx, s.stg, account, t.Type, t.Amount)
+	}
+	if err != nil || isTransfer(t.Type) {
+		return err
+	}
+
+	baseAmount, err := convertAmount(ctx, s.stg, float64(t.Amount), t.Currency, s.baseCurrency, s.baseCurrency, dayIn(t.Date, t.TimeZone))
+	if err != nil {
+		return err
+	}
+	if undo {
+		baseAmount = -baseAmount
+	}
+	if t.Type == "-" {
+		return s.stg.Budget().UpdateBudgetAmount(ctx, t.UserId, float32(baseAmount))
+	}
+	return s.stg.Goal().UpdateGoalAmount(ctx, t.UserId, float32(baseAmount))
+}
+
+// DeleteTransaction moves a transaction to the trash and takes it off the account
+// balance. Deleting either side of a transfer deletes the whole transfer.
 func (s *TransactionService) DeleteTransaction(ctx context.Context, req *pb.DeleteTransactionRequest) (*pb.TransactionDeleteResponse, error) {
-	resp, err := s.stg.Transaction().DeleteTransaction(req)
+	legs, err := s.transferLegs(ctx, req.TransactionId)
 	if err != nil {
 		log.Printf("Failed to delete transaction: %v", err)
 		return nil, err
 	}
-	return resp, nil
+	for _, t := range legs {
+		if t.Reconciled {
+			err = apperr.FailedPrecondition("not_reconciled", "transaction %s is reconciled and can't be deleted", t.TransactionId)
+			log.Printf("Failed to delete transaction: %v", err)
+			return &pb.TransactionDeleteResponse{Success: false}, err
+		}
+	}
+
+	for _, t := range legs {
+		resp, err := s.stg.Transaction().DeleteTransaction(ctx, &pb.DeleteTransactionRequest{TransactionId: t.TransactionId})
+		if err != nil {
+			log.Printf("Failed to delete transaction: %v", err)
+			return resp, err
+		}
+		if err := s.book(ctx, t, true); err != nil {
+			log.Printf("Failed to update balances for deleted transaction: %v", err)
+			return &pb.TransactionDeleteResponse{Success: false}, err
+		}
+	}
+	return &pb.TransactionDeleteResponse{Success: true}, nil
+}
+
+// RestoreTransaction takes a transaction, or a whole transfer, out of the trash and books it again
+func (s *TransactionService) RestoreTransaction(ctx context.Context, req *pb.RestoreTransactionRequest) (*pb.Response, error) {
+	if err := s.stg.Transaction().RestoreTransaction(ctx, req.TransactionId); err != nil {
+		log.Printf("Failed to restore transaction: %v", err)
+		return &pb.Response{Message: "Failed to restore transaction"}, err
+	}
+
+	legs, err := s.transferLegs(ctx, req.TransactionId)
+	if err != nil {
+		log.Printf("Failed to restore transaction: %v", err)
+		return &pb.Response{Message: "Failed to restore transaction"}, err
+	}
+	for _, t := range legs {
+		if err := s.book(ctx, t, false); err != nil {
+			log.Printf("Failed to update balances for restored transaction: %v", err)
+			return &pb.Response{Message: "Failed to update account balance"}, err
+		}
+	}
+	return &pb.Response{Message: "Transaction restored successfully"}, nil
+}
+
+// CreateTransfer moves money between two of the user's accounts. Paying a credit
+// card or loan from a checking account is a transfer, so it lowers both the
+// checking balance and the amount owed without counting as spending or income.
+func (s *TransactionService) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.TransferResponse, error) {
+	if req.Amount <= 0 {
+		return &pb.TransferResponse{Message: "Invalid transfer amount"}, apperr.InvalidArgument("amount", "transfer amount must be positive")
+	}
+	if req.FromAccountId == req.ToAccountId {
+		return &pb.TransferResponse{Message: "Cannot transfer to the same account"}, apperr.InvalidArgument("to_account_id", "source and destination accounts are the same")
+	}
+	zone, err := s.timeZone(ctx, req.UserId, req.TimeZone)
+	if err != nil {
+		log.Printf("Failed to get user settings: %v", err)
+		return &pb.TransferResponse{Message: "Failed to get user settings"}, err
+	}
+	req.TimeZone = zone
+
+	from, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.FromAccountId})
+	if err != nil {
+		log.Printf("Failed to get source account: %v", err)
+		return &pb.TransferResponse{Message: "Failed to get source account"}, err
+	}
+	to, err := s.stg.Account().GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: req.ToAccountId})
+	if err != nil {
+		log.Printf("Failed to get destination account: %v", err)
+		return &pb.TransferResponse{Message: "Failed to get destination account"}, err
+	}
+	for _, account := range []*pb.AccountResponse{from, to} {
+		if err := checkOpen(account); err != nil {
+			log.Printf("Failed to create transfer: %v", err)
+			return &pb.TransferResponse{Message: "Account is archived"}, err
+		}
+		if err := checkEditable(ctx, s.stg, account.HouseholdId, req.UserId); err != nil {
+			log.Printf("Failed to create transfer: %v", err)
+			return &pb.TransferResponse{Message: "Account is shared read-only"}, err
+		}
+	}
+
+	if isLiability(from.AccountType) && from.CreditLimit > 0 && from.Balance+float64(req.Amount) > from.CreditLimit {
+		err = apperr.FailedPrecondition("credit_limit", "amount %.2f exceeds the available credit of %.2f", req.Amount, from.AvailableCredit)
+		log.Printf("Failed to create transfer: %v", err)
+		return &pb.TransferResponse{Message: "Credit limit exceeded"}, err
+	}
+
+	fromCurrency, toCurrency := from.Currency, to.Currency
+	if fromCurrency == "" {
+		fromCurrency = s.baseCurrency
+	}
+	if toCurrency == "" {
+		toCurrency = s.baseCurrency
+	}
+	toAmount, err := convertAmount(ctx, s.stg, float64(req.Amount), fromCurrency, toCurrency, s.baseCurrency, dayIn(req.Date, req.TimeZone))
+	if err != nil {
+		log.Printf("Failed to convert transfer amount: %v", err)
+		return &pb.TransferResponse{Message: "Failed to convert transfer amount"}, err
+	}
+
+	transferId := uuid.NewString()
+	out := &pb.CreateTransactionRequest{
+		UserId:           req.UserId,
+		AccountId:        from.AccountId,
+		Amount:           req.Amount,
+		Type:             transferOut,
+		Description:      req.Description,
+		Date:             req.Date,
+		TimeZone:         req.TimeZone,
+		Currency:         fromCurrency,
+		OriginalAmount:   req.Amount,
+		OriginalCurrency: fromCurrency,
+		ExchangeRate:     1,
+		TransferId:       transferId,
+		HouseholdId:      from.HouseholdId,
+	}
+	in := &pb.CreateTransactionRequest{
+		UserId:           req.UserId,
+		AccountId:        to.AccountId,
+		Amount:           float32(toAmount),
+		Type:             transferIn,
+		Description:      req.Description,
+		Date:             req.Date,
+		TimeZone:         req.TimeZone,
+		Currency:         toCurrency,
+		OriginalAmount:   req.Amount,
+		OriginalCurrency: fromCurrency,
+		ExchangeRate:     toAmount / float64(req.Amount),
+		TransferId:       transferId,
+		HouseholdId:      to.HouseholdId,
+	}
+
+	for _, t := range []struct {
+		account *pb.AccountResponse
+		req     *pb.CreateTransactionRequest
+	}{{from, out}, {to, in}} {
+		if err := bookTransferLeg(ctx, s.stg, t.account, t.req); err != nil {
+			log.Printf("Failed to create transfer: %v", err)
+			return &pb.TransferResponse{Message: "Failed to create transfer"}, err
+		}
+	}
+
+	return &pb.TransferResponse{
+		TransferId:        transferId,
+		FromTransactionId: out.Id,
+		ToTransactionId:   in.Id,
+		ToAmount:          in.Amount,
+		Message:           "Transfer created successfully",
+	}, nil
+}
+
+// bookTransferLeg stores one side of a transfer and applies it to the account's balance
+func bookTransferLeg(ctx context.Context, stg mdb.InitRoot, account *pb.AccountResponse, leg *pb.CreateTransactionRequest) error {
+	if _, err := stg.Transaction().CreateTransaction(ctx, leg); err != nil {
+		return err
+	}
+	return applyToBalance(ctx, stg, account, leg.Type, leg.Amount)
 }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	mdb "budget-service/storage"
+)
+
+// TrashPurger permanently removes deleted items once they are older than the retention window
+type TrashPurger struct {
+	stg       mdb.InitRoot
+	retention time.Duration
+}
+
+func NewTrashPurger(db mdb.InitRoot, retentionDays int) *TrashPurger {
+	return &TrashPurger{stg: db, retention: time.Duration(retentionDays) * 24 * time.Hour}
+}
+
+// Purge deletes everything that has been in the trash longer than the retention window
+func (p *TrashPurger) Purge(ctx context.Context, now time.Time) error {
+	purged, err := p.stg.Trash().Purge(ctx, now.Add(-p.retention))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("Purged %d items from the trash", purged)
+	}
+	return nil
+}
+
+// RunDaily purges the trash right away and then once a day until ctx is cancelled
+func (p *TrashPurger) RunDaily(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Purge(ctx, time.Now()); err != nil {
+			log.Printf("Failed to purge the trash: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
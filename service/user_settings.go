@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"budget-service/apperr"
+	"budget-service/dates"
+	pb "budget-service/genproto"
+	mdb "budget-service/storage"
+)
+
+// Settings used for users who haven't chosen their own
+const (
+	defaultTimeZone  = "UTC"
+	defaultWeekStart = "monday"
+	defaultLocale    = "en"
+)
+
+type UserSettingsService struct {
+	stg          mdb.InitRoot
+	baseCurrency string
+	pb.UnimplementedUserSettingsServiceServer
+}
+
+func NewUserSettingsService(db mdb.InitRoot, baseCurrency string) *UserSettingsService {
+	return &UserSettingsService{stg: db, baseCurrency: baseCurrency}
+}
+
+// forUser returns the user's settings with the defaults filled in for anything
+// they haven't set. Users without stored settings get version 0.
+func (s *UserSettingsService) forUser(ctx context.Context, userId string) (*pb.UserSettings, error) {
+	settings, err := s.stg.UserSettings().GetUserSettings(ctx, userId)
+	if err != nil {
+		if !apperr.Is(err, apperr.KindNotFound) {
+			return nil, err
+		}
+		settings = &pb.UserSettings{UserId: userId}
+	}
+	if settings.TimeZone == "" {
+		settings.TimeZone = defaultTimeZone
+	}
+	if settings.BaseCurrency == "" {
+		settings.BaseCurrency = s.baseCurrency
+	}
+	if settings.WeekStart == "" {
+		settings.WeekStart = defaultWeekStart
+	}
+	if settings.Locale == "" {
+		settings.Locale = defaultLocale
+	}
+	return settings, nil
+}
+
+// now returns the current time in the user's time zone and the day their week starts on
+func (s *UserSettingsService) now(ctx context.Context, userId string) (time.Time, time.Weekday, error) {
+	settings, err := s.forUser(ctx, userId)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return time.Now().In(dates.Location(settings.TimeZone)), weekday(settings.WeekStart), nil
+}
+
+func (s *UserSettingsService) GetUserSettings(ctx context.Context, req *pb.GetUserSettingsRequest) (*pb.UserSettings, error) {
+	resp, err := s.forUser(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *UserSettingsService) UpdateUserSettings(ctx context.Context, req *pb.UpdateUserSettingsRequest) (*pb.UserSettings, error) {
+	if _, err := s.stg.UserSettings().UpdateUserSettings(ctx, req); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	resp, err := s.forUser(ctx, req.UserId)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return resp, nil
+}
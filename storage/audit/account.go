@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+
+	pb "budget-service/genproto"
+	"budget-service/storage"
+)
+
+type accountStorage struct {
+	storage.AccountStorage
+	recorder
+}
+
+func (s *accountStorage) get(ctx context.Context, accountID string) *pb.AccountResponse {
+	account, err := s.AccountStorage.GetAccountById(ctx, &pb.GetAccountByIdRequest{AccountId: accountID})
+	if err != nil {
+		return nil
+	}
+	return account
+}
+
+// audited runs a change to one account and records it with before and after snapshots
+func (s *accountStorage) audited(ctx context.Context, accountID, operation string, mutate func() error) error {
+	before := s.get(ctx, accountID)
+	if err := mutate(); err != nil {
+		return err
+	}
+	after := s.get(ctx, accountID)
+	if before == nil && after == nil {
+		return nil
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "account", EntityId: accountID, Operation: operation}, before, after)
+	return nil
+}
+
+func (s *accountStorage) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountRes, error) {
+	resp, err := s.AccountStorage.CreateAccount(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "account", EntityId: req.Id, Operation: "create"}, nil, s.get(ctx, req.Id))
+	return resp, nil
+}
+
+func (s *accountStorage) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (resp *pb.CreateAccountRes, err error) {
+	err = s.audited(ctx, req.AccountId, "update", func() error {
+		resp, err = s.AccountStorage.UpdateAccount(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *accountStorage) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (resp *pb.DeleteResponse, err error) {
+	err = s.audited(ctx, req.AccountId, "delete", func() error {
+		resp, err = s.AccountStorage.DeleteAccount(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *accountStorage) UpdateBalance(ctx context.Context, accountID string, amount float32) error {
+	return s.audited(ctx, accountID, "update_balance", func() error {
+		return s.AccountStorage.UpdateBalance(ctx, accountID, amount)
+	})
+}
+
+func (s *accountStorage) UpdateBalanceMinus(ctx context.Context, accountID string, amount float32) error {
+	return s.audited(ctx, accountID, "update_balance", func() error {
+		return s.AccountStorage.UpdateBalanceMinus(ctx, accountID, amount)
+	})
+}
+
+func (s *accountStorage) SetBalance(ctx context.Context, accountID string, balance float64) error {
+	return s.audited(ctx, accountID, "set_balance", func() error {
+		return s.AccountStorage.SetBalance(ctx, accountID, balance)
+	})
+}
+
+func (s *accountStorage) SetArchived(ctx context.Context, accountID string, archived bool) error {
+	operation := "reopen"
+	if archived {
+		operation = "archive"
+	}
+	return s.audited(ctx, accountID, operation, func() error {
+		return s.AccountStorage.SetArchived(ctx, accountID, archived)
+	})
+}
+
+func (s *accountStorage) RestoreAccount(ctx context.Context, accountID string) error {
+	return s.audited(ctx, accountID, "restore", func() error {
+		return s.AccountStorage.RestoreAccount(ctx, accountID)
+	})
+}
+
+func (s *accountStorage) SetHousehold(ctx context.Context, accountID, householdID string) error {
+	return s.audited(ctx, accountID, "share", func() error {
+		return s.AccountStorage.SetHousehold(ctx, accountID, householdID)
+	})
+}
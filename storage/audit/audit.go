@@ -0,0 +1,144 @@
+// Package audit wraps a storage.InitRoot so that every mutation it performs is
+// appended to the audit log with the actor, the request id and snapshots of the
+// entity before and after the change.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"budget-service/appctx"
+	pb "budget-service/genproto"
+	"budget-service/storage"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// systemActor is recorded for changes made outside of a request, such as scheduled jobs
+const systemActor = "system"
+
+type root struct {
+	storage.InitRoot
+	recorder
+}
+
+// New returns an InitRoot that records the mutations made through inner
+func New(inner storage.InitRoot) storage.InitRoot {
+	return &root{InitRoot: inner, recorder: recorder{log: inner.AuditLog()}}
+}
+
+func (r *root) Account() storage.AccountStorage {
+	return &accountStorage{AccountStorage: r.InitRoot.Account(), recorder: r.recorder}
+}
+
+func (r *root) Budget() storage.BudgetStorage {
+	return &budgetStorage{BudgetStorage: r.InitRoot.Budget(), recorder: r.recorder}
+}
+
+func (r *root) Category() storage.CategoryStorage {
+	return &categoryStorage{CategoryStorage: r.InitRoot.Category(), recorder: r.recorder}
+}
+
+func (r *root) Goal() storage.GoalStorage {
+	return &goalStorage{GoalStorage: r.InitRoot.Goal(), recorder: r.recorder}
+}
+
+func (r *root) Transaction() storage.TransactionStorage {
+	return &transactionStorage{TransactionStorage: r.InitRoot.Transaction(), recorder: r.recorder}
+}
+
+func (r *root) Notification() storage.NotificationService {
+	return &notificationStorage{NotificationService: r.InitRoot.Notification(), recorder: r.recorder}
+}
+
+func (r *root) ExchangeRate() storage.ExchangeRateStorage {
+	return &exchangeRateStorage{ExchangeRateStorage: r.InitRoot.ExchangeRate(), recorder: r.recorder}
+}
+
+func (r *root) Reconciliation() storage.ReconciliationStorage {
+	return &reconciliationStorage{ReconciliationStorage: r.InitRoot.Reconciliation(), recorder: r.recorder}
+}
+
+func (r *root) Trash() storage.TrashStorage {
+	return &trashStorage{TrashStorage: r.InitRoot.Trash(), recorder: r.recorder}
+}
+
+func (r *root) UserSettings() storage.UserSettingsStorage {
+	return &userSettingsStorage{UserSettingsStorage: r.InitRoot.UserSettings(), recorder: r.recorder}
+}
+
+func (r *root) Household() storage.HouseholdStorage {
+	return &householdStorage{HouseholdStorage: r.InitRoot.Household(), recorder: r.recorder}
+}
+
+func (r *root) Split() storage.SplitStorage {
+	return &splitStorage{SplitStorage: r.InitRoot.Split(), recorder: r.recorder}
+}
+
+type recorder struct {
+	log storage.AuditLogStorage
+}
+
+// record fills in who, when and the snapshots, and appends the event. A failed
+// write is logged rather than returned because the change itself already happened.
+func (r recorder) record(ctx context.Context, event *pb.AuditEvent, before, after interface{}) {
+	event.Actor = appctx.Actor(ctx)
+	if event.Actor == "" {
+		event.Actor = systemActor
+	}
+	event.RequestId = appctx.RequestID(ctx)
+	if event.UserId == "" {
+		event.UserId = owner(after)
+	}
+	if event.UserId == "" {
+		event.UserId = owner(before)
+	}
+	event.Before = snapshot(before)
+	event.After = snapshot(after)
+	event.CreatedAt = time.Now().Format(time.RFC3339)
+
+	if err := r.log.AppendAuditEvent(ctx, event); err != nil {
+		log.Printf("Failed to audit %s of %s %s: %v", event.Operation, event.Entity, event.EntityId, err)
+	}
+}
+
+// snapshot encodes an entity as JSON, using the proto field names for messages
+func snapshot(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if m, ok := v.(proto.Message); ok {
+		if !m.ProtoReflect().IsValid() {
+			return ""
+		}
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(m)
+		if err != nil {
+			log.Printf("Failed to encode audit snapshot: %v", err)
+			return ""
+		}
+		return string(data)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Failed to encode audit snapshot: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+// owner returns the user_id of an entity snapshot, if it has one
+func owner(v interface{}) string {
+	m, ok := v.(proto.Message)
+	if !ok || !m.ProtoReflect().IsValid() {
+		return ""
+	}
+	r := m.ProtoReflect()
+	field := r.Descriptor().Fields().ByName("user_id")
+	if field == nil {
+		return ""
+	}
+	return r.Get(field).String()
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"context"
+
+	pb "budget-service/genproto"
+	"budget-service/storage"
+)
+
+type budgetStorage struct {
+	storage.BudgetStorage
+	recorder
+}
+
+func (s *budgetStorage) get(ctx context.Context, budgetID string) *pb.BudgetResponse {
+	budget, err := s.BudgetStorage.GetBudgetById(ctx, &pb.GetBudgetByIdRequest{BudgetId: budgetID})
+	if err != nil {
+		return nil
+	}
+	return budget
+}
+
+// audited runs a change to one budget and records it with before and after snapshots
+func (s *budgetStorage) audited(ctx context.Context, budgetID, operation string, mutate func() error) error {
+	before := s.get(ctx, budgetID)
+	if err := mutate(); err != nil {
+		return err
+	}
+	after := s.get(ctx, budgetID)
+	if before == nil && after == nil {
+		return nil
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "budget", EntityId: budgetID, Operation: operation}, before, after)
+	return nil
+}
+
+func (s *budgetStorage) CreateBudget(ctx context.Context, req *pb.CreateBudgetRequest) (*pb.MessageResponsee, error) {
+	resp, err := s.BudgetStorage.CreateBudget(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "budget", EntityId: req.Id, Operation: "create"}, nil, s.get(ctx, req.Id))
+	return resp, nil
+}
+
+func (s *budgetStorage) UpdateBudget(ctx context.Context, req *pb.UpdateBudgetRequest) (resp *pb.MessageResponsee, err error) {
+	err = s.audited(ctx, req.BudgetId, "update", func() error {
+		resp, err = s.BudgetStorage.UpdateBudget(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *budgetStorage) DeleteBudget(ctx context.Context, req *pb.DeleteBudgetRequest) (resp *pb.BudgetDeleteResponse, err error) {
+	err = s.audited(ctx, req.BudgetId, "delete", func() error {
+		resp, err = s.BudgetStorage.DeleteBudget(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *budgetStorage) RestoreBudget(ctx context.Context, budgetID string) error {
+	return s.audited(ctx, budgetID, "restore", func() error {
+		return s.BudgetStorage.RestoreBudget(ctx, budgetID)
+	})
+}
+
+// UpdateBudgetAmount spends from the user's budget, so the event carries the user rather than a budget ID
+func (s *budgetStorage) UpdateBudgetAmount(ctx context.Context, userId string, amount float32) error {
+	if err := s.BudgetStorage.UpdateBudgetAmount(ctx, userId, amount); err != nil {
+		return err
+	}
+	change := map[string]float32{"amount_change": -amount}
+	s.record(ctx, &pb.AuditEvent{Entity: "budget", Operation: "spend", UserId: userId}, nil, change)
+	return nil
+}
+
+func (s *budgetStorage) SetHousehold(ctx context.Context, budgetID, householdID string) error {
+	return s.audited(ctx, budgetID, "share", func() error {
+		return s.BudgetStorage.SetHousehold(ctx, budgetID, householdID)
+	})
+}
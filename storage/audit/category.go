@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+
+	pb "budget-service/genproto"
+	"budget-service/storage"
+)
+
+type categoryStorage struct {
+	storage.CategoryStorage
+	recorder
+}
+
+func (s *categoryStorage) get(ctx context.Context, categoryID string) *pb.CategoryResponse {
+	category, err := s.CategoryStorage.GetCategoryById(ctx, &pb.GetCategoryByIdRequest{CategoryId: categoryID})
+	if err != nil {
+		return nil
+	}
+	return category
+}
+
+// audited runs a change to one category and records it with before and after snapshots
+func (s *categoryStorage) audited(ctx context.Context, categoryID, operation string, mutate func() error) error {
+	before := s.get(ctx, categoryID)
+	if err := mutate(); err != nil {
+		return err
+	}
+	after := s.get(ctx, categoryID)
+	if before == nil && after == nil {
+		return nil
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "category", EntityId: categoryID, Operation: operation}, before, after)
+	return nil
+}
+
+func (s *categoryStorage) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.MessageResponse, error) {
+	resp, err := s.CategoryStorage.CreateCategory(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "category", EntityId: req.Id, Operation: "create"}, nil, s.get(ctx, req.Id))
+	return resp, nil
+}
+
+func (s *categoryStorage) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (resp *pb.MessageResponse, err error) {
+	err = s.audited(ctx, req.CategoryId, "update", func() error {
+		resp, err = s.CategoryStorage.UpdateCategory(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *categoryStorage) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (resp *pb.CategoryDeleteResponse, err error) {
+	err = s.audited(ctx, req.CategoryId, "delete", func() error {
+		resp, err = s.CategoryStorage.DeleteCategory(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *categoryStorage) RestoreCategory(ctx context.Context, categoryID string) error {
+	return s.audited(ctx, categoryID, "restore", func() error {
+		return s.CategoryStorage.RestoreCategory(ctx, categoryID)
+	})
+}
+
+func (s *categoryStorage) SeedDefaultCategories(ctx context.Context, req *pb.SeedDefaultCategoriesRequest) (*pb.SeedDefaultCategoriesResponse, error) {
+	resp, err := s.CategoryStorage.SeedDefaultCategories(ctx, req)
+	if err != nil || resp.Seeded == 0 {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "category", Operation: "seed", UserId: req.UserId}, nil, resp)
+	return resp, nil
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"context"
+
+	pb "budget-service/genproto"
+	"budget-service/storage"
+)
+
+type goalStorage struct {
+	storage.GoalStorage
+	recorder
+}
+
+func (s *goalStorage) get(ctx context.Context, goalID string) *pb.GoalResponse {
+	goal, err := s.GoalStorage.GetGoalById(ctx, &pb.GetGoalByIdRequest{GoalId: goalID})
+	if err != nil {
+		return nil
+	}
+	return goal
+}
+
+// audited runs a change to one goal and records it with before and after snapshots
+func (s *goalStorage) audited(ctx context.Context, goalID, operation string, mutate func() error) error {
+	before := s.get(ctx, goalID)
+	if err := mutate(); err != nil {
+		return err
+	}
+	after := s.get(ctx, goalID)
+	if before == nil && after == nil {
+		return nil
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "goal", EntityId: goalID, Operation: operation}, before, after)
+	return nil
+}
+
+func (s *goalStorage) CreateGoal(ctx context.Context, req *pb.CreateGoalRequest) (*pb.Responsee, error) {
+	resp, err := s.GoalStorage.CreateGoal(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "goal", EntityId: req.Id, Operation: "create"}, nil, s.get(ctx, req.Id))
+	return resp, nil
+}
+
+func (s *goalStorage) UpdateGoal(ctx context.Context, req *pb.UpdateGoalRequest) (resp *pb.Responsee, err error) {
+	err = s.audited(ctx, req.GoalId, "update", func() error {
+		resp, err = s.GoalStorage.UpdateGoal(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *goalStorage) DeleteGoal(ctx context.Context, req *pb.DeleteGoalRequest) (resp *pb.GoalDeleteResponse, err error) {
+	err = s.audited(ctx, req.GoalId, "delete", func() error {
+		resp, err = s.GoalStorage.DeleteGoal(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *goalStorage) RestoreGoal(ctx context.Context, goalID string) error {
+	return s.audited(ctx, goalID, "restore", func() error {
+		return s.GoalStorage.RestoreGoal(ctx, goalID)
+	})
+}
+
+// UpdateGoalAmount saves towards the user's goal, so the event carries the user rather than a goal ID
+func (s *goalStorage) UpdateGoalAmount(ctx context.Context, userId string, amount float32) error {
+	if err := s.GoalStorage.UpdateGoalAmount(ctx, userId, amount); err != nil {
+		return err
+	}
+	change := map[string]float32{"amount_change": amount}
+	s.record(ctx, &pb.AuditEvent{Entity: "goal", Operation: "contribute", UserId: userId}, nil, change)
+	return nil
+}
+
+// CheckGoal closes the goal with a new status once its deadline is reached
+func (s *goalStorage) CheckGoal(ctx context.Context, userId string) (bool, string, error) {
+	ok, message, err := s.GoalStorage.CheckGoal(ctx, userId)
+	if err != nil || ok {
+		return ok, message, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "goal", Operation: "update_status", UserId: userId}, nil, map[string]string{"message": message})
+	return ok, message, nil
+}
@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+
+	pb "budget-service/genproto"
+	"budget-service/model"
+	"budget-service/storage"
+)
+
+type notificationStorage struct {
+	storage.NotificationService
+	recorder
+}
+
+func (s *notificationStorage) CreateNotification(ctx context.Context, req model.Send) error {
+	if err := s.NotificationService.CreateNotification(ctx, req); err != nil {
+		return err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "notification", Operation: "create", UserId: req.UserId}, nil, req)
+	return nil
+}
+
+func (s *notificationStorage) DeleteNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.NotificationsResponse, error) {
+	before, err := s.NotificationService.GetNotification(ctx, req)
+	if err != nil {
+		before = nil
+	}
+
+	resp, err := s.NotificationService.DeleteNotification(ctx, req)
+	if err != nil || before == nil || !resp.Success {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "notification", EntityId: before.NotificationId, Operation: "delete"}, before, nil)
+	return resp, nil
+}
+
+func (s *notificationStorage) RestoreNotification(ctx context.Context, notificationID string) error {
+	if err := s.NotificationService.RestoreNotification(ctx, notificationID); err != nil {
+		return err
+	}
+	after, err := s.NotificationService.GetNotification(ctx, &pb.GetNotificationByidRequest{NotificationId: notificationID})
+	if err != nil {
+		after = nil
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "notification", EntityId: notificationID, Operation: "restore"}, nil, after)
+	return nil
+}
@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	pb "budget-service/genproto"
+	"budget-service/storage"
+)
+
+type exchangeRateStorage struct {
+	storage.ExchangeRateStorage
+	recorder
+}
+
+func (s *exchangeRateStorage) SetExchangeRate(ctx context.Context, req *pb.ExchangeRate) (*pb.ExchangeRateResponse, error) {
+	resp, err := s.ExchangeRateStorage.SetExchangeRate(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	id := req.BaseCurrency + "/" + req.QuoteCurrency + "/" + req.Date
+	s.record(ctx, &pb.AuditEvent{Entity: "exchange_rate", EntityId: id, Operation: "set"}, nil, req)
+	return resp, nil
+}
+
+func (s *exchangeRateStorage) LoadExchangeRates(ctx context.Context, path string) (int, error) {
+	loaded, err := s.ExchangeRateStorage.LoadExchangeRates(ctx, path)
+	if err != nil {
+		return loaded, err
+	}
+	file := map[string]interface{}{"path": path, "loaded": loaded}
+	s.record(ctx, &pb.AuditEvent{Entity: "exchange_rate", Operation: "load"}, nil, file)
+	return loaded, nil
+}
+
+type reconciliationStorage struct {
+	storage.ReconciliationStorage
+	recorder
+}
+
+func (s *reconciliationStorage) CreateReconciliation(ctx context.Context, rec *pb.Reconciliation) error {
+	if err := s.ReconciliationStorage.CreateReconciliation(ctx, rec); err != nil {
+		return err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "reconciliation", EntityId: rec.ReconciliationId, Operation: "create"}, nil, rec)
+	return nil
+}
+
+type trashStorage struct {
+	storage.TrashStorage
+	recorder
+}
+
+func (s *trashStorage) Purge(ctx context.Context, before time.Time) (int64, error) {
+	purged, err := s.TrashStorage.Purge(ctx, before)
+	if err != nil || purged == 0 {
+		return purged, err
+	}
+	result := map[string]interface{}{"deleted_before": before.Format(time.RFC3339), "purged": purged}
+	s.record(ctx, &pb.AuditEvent{Entity: "trash", Operation: "purge"}, nil, result)
+	return purged, nil
+}
+
+type userSettingsStorage struct {
+	storage.UserSettingsStorage
+	recorder
+}
+
+func (s *userSettingsStorage) UpdateUserSettings(ctx context.Context, req *pb.UpdateUserSettingsRequest) (*pb.UserSettings, error) {
+	// Settings that don't exist yet leave before empty
+	before, _ := s.UserSettingsStorage.GetUserSettings(ctx, req.UserId)
+	after, err := s.UserSettingsStorage.UpdateUserSettings(ctx, req)
+	if err != nil {
+		return after, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "user_settings", EntityId: req.UserId, Operation: "update"}, before, after)
+	return after, nil
+}
+
+type householdStorage struct {
+	storage.HouseholdStorage
+	recorder
+}
+
+func (s *householdStorage) CreateHousehold(ctx context.Context, name, ownerID string) (*pb.Household, error) {
+	household, err := s.HouseholdStorage.CreateHousehold(ctx, name, ownerID)
+	if err != nil {
+		return household, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "household", EntityId: household.HouseholdId, Operation: "create", UserId: ownerID}, nil, household)
+	return household, nil
+}
+
+func (s *householdStorage) UpdateHousehold(ctx context.Context, household *pb.Household) error {
+	before, _ := s.HouseholdStorage.GetHouseholdById(ctx, household.HouseholdId)
+	if err := s.HouseholdStorage.UpdateHousehold(ctx, household); err != nil {
+		return err
+	}
+	after, _ := s.HouseholdStorage.GetHouseholdById(ctx, household.HouseholdId)
+	s.record(ctx, &pb.AuditEvent{Entity: "household", EntityId: household.HouseholdId, Operation: "update"}, before, after)
+	return nil
+}
+
+func (s *householdStorage) DeleteHousehold(ctx context.Context, householdID string) error {
+	before, _ := s.HouseholdStorage.GetHouseholdById(ctx, householdID)
+	if err := s.HouseholdStorage.DeleteHousehold(ctx, householdID); err != nil {
+		return err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "household", EntityId: householdID, Operation: "delete"}, before, nil)
+	return nil
+}
+
+type splitStorage struct {
+	storage.SplitStorage
+	recorder
+}
+
+func (s *splitStorage) CreateSplit(ctx context.Context, split *pb.SplitExpense) error {
+	if err := s.SplitStorage.CreateSplit(ctx, split); err != nil {
+		return err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "split_expense", EntityId: split.SplitId, Operation: "create"}, nil, split)
+	return nil
+}
+
+func (s *splitStorage) CreateSettlement(ctx context.Context, settlement *pb.Settlement) error {
+	if err := s.SplitStorage.CreateSettlement(ctx, settlement); err != nil {
+		return err
+	}
+	event := &pb.AuditEvent{Entity: "settlement", EntityId: settlement.SettlementId, Operation: "create", UserId: settlement.DebtorId}
+	s.record(ctx, event, nil, settlement)
+	return nil
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+
+	pb "budget-service/genproto"
+	"budget-service/storage"
+)
+
+type transactionStorage struct {
+	storage.TransactionStorage
+	recorder
+}
+
+func (s *transactionStorage) get(ctx context.Context, transactionID string) *pb.TransactionResponse {
+	transaction, err := s.TransactionStorage.GetTransactionById(ctx, &pb.GetTransactionByIdRequest{TransactionId: transactionID})
+	if err != nil {
+		return nil
+	}
+	return transaction
+}
+
+// audited runs a change to one transaction and records it with before and after snapshots
+func (s *transactionStorage) audited(ctx context.Context, transactionID, operation string, mutate func() error) error {
+	before := s.get(ctx, transactionID)
+	if err := mutate(); err != nil {
+		return err
+	}
+	after := s.get(ctx, transactionID)
+	if before == nil && after == nil {
+		return nil
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "transaction", EntityId: transactionID, Operation: operation}, before, after)
+	return nil
+}
+
+func (s *transactionStorage) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.Response, error) {
+	resp, err := s.TransactionStorage.CreateTransaction(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	s.record(ctx, &pb.AuditEvent{Entity: "transaction", EntityId: req.Id, Operation: "create"}, nil, s.get(ctx, req.Id))
+	return resp, nil
+}
+
+func (s *transactionStorage) UpdateTransaction(ctx context.Context, req *pb.UpdateTransactionRequest) (resp *pb.Response, err error) {
+	err = s.audited(ctx, req.TransactionId, "update", func() error {
+		resp, err = s.TransactionStorage.UpdateTransaction(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *transactionStorage) DeleteTransaction(ctx context.Context, req *pb.DeleteTransactionRequest) (resp *pb.TransactionDeleteResponse, err error) {
+	err = s.audited(ctx, req.TransactionId, "delete", func() error {
+		resp, err = s.TransactionStorage.DeleteTransaction(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (s *transactionStorage) RestoreTransaction(ctx context.Context, transactionID string) error {
+	return s.audited(ctx, transactionID, "restore", func() error {
+		return s.TransactionStorage.RestoreTransaction(ctx, transactionID)
+	})
+}
+
+// SetCleared records one event per transaction whose cleared flag changed
+func (s *transactionStorage) SetCleared(ctx context.Context, accountId string, transactionIds []string, cleared bool) (int64, error) {
+	before := make(map[string]*pb.TransactionResponse, len(transactionIds))
+	for _, id := range transactionIds {
+		before[id] = s.get(ctx, id)
+	}
+
+	updated, err := s.TransactionStorage.SetCleared(ctx, accountId, transactionIds, cleared)
+	if err != nil {
+		return updated, err
+	}
+
+	for _, id := range transactionIds {
+		after := s.get(ctx, id)
+		if before[id] == nil || after == nil || before[id].Cleared == after.Cleared {
+			continue
+		}
+		s.record(ctx, &pb.AuditEvent{Entity: "transaction", EntityId: id, Operation: "set_cleared"}, before[id], after)
+	}
+	return updated, nil
+}
+
+// MarkReconciled locks transactions in bulk, so it is recorded once against the account
+func (s *transactionStorage) MarkReconciled(ctx context.Context, accountId string, transactionIds []string) error {
+	if err := s.TransactionStorage.MarkReconciled(ctx, accountId, transactionIds); err != nil {
+		return err
+	}
+	reconciled := map[string]interface{}{"account_id": accountId, "transaction_ids": transactionIds}
+	s.record(ctx, &pb.AuditEvent{Entity: "account", EntityId: accountId, Operation: "mark_reconciled"}, nil, reconciled)
+	return nil
+}
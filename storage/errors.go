@@ -0,0 +1,7 @@
+package storage
+
+import "errors"
+
+// ErrVersionConflict is returned when an update carries a version other than the
+// stored one, meaning someone else changed the item since the client read it
+var ErrVersionConflict = errors.New("version conflict: the item was changed since it was read")
@@ -4,6 +4,7 @@ import (
 	pb "budget-service/genproto"
 	"budget-service/model"
 	"context"
+	"time"
 )
 
 type InitRoot interface {
@@ -13,57 +14,127 @@ type InitRoot interface {
 	Goal() GoalStorage
 	Transaction() TransactionStorage
 	Notification() NotificationService
+	ExchangeRate() ExchangeRateStorage
+	NetWorth() NetWorthStorage
+	Reconciliation() ReconciliationStorage
+	Trash() TrashStorage
+	AuditLog() AuditLogStorage
+	UserSettings() UserSettingsStorage
+	Household() HouseholdStorage
+	Split() SplitStorage
 }
 
 type AccountStorage interface {
-	CreateAccount(req *pb.CreateAccountRequest) (*pb.CreateAccountRes, error)
-	ListAccounts(req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error)
-	GetAccountById(req *pb.GetAccountByIdRequest) (*pb.AccountResponse, error)
-	UpdateAccount(req *pb.UpdateAccountRequest) (*pb.CreateAccountRes, error)
-	DeleteAccount(req *pb.DeleteAccountRequest) (*pb.DeleteResponse, error)
+	CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountRes, error)
+	ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error)
+	GetAccountById(ctx context.Context, req *pb.GetAccountByIdRequest) (*pb.AccountResponse, error)
+	UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.CreateAccountRes, error)
+	DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteResponse, error)
 	UpdateBalance(ctx context.Context, accountID string, amount float32) error
 	UpdateBalanceMinus(ctx context.Context, accountID string, amount float32) error
+	SetBalance(ctx context.Context, accountID string, balance float64) error
+	SetArchived(ctx context.Context, accountID string, archived bool) error
+	RestoreAccount(ctx context.Context, accountID string) error
+	SetHousehold(ctx context.Context, accountID, householdID string) error
+	SetRemindedDue(ctx context.Context, accountID, dueDate string) (bool, error)
 }
 
 type BudgetStorage interface {
-	CreateBudget(req *pb.CreateBudgetRequest) (*pb.MessageResponsee, error)
-	ListBudgets(req *pb.ListBudgetsRequest) (*pb.ListBudgetsResponse, error)
-	GetBudgetById(req *pb.GetBudgetByIdRequest) (*pb.BudgetResponse, error)
-	UpdateBudget(req *pb.UpdateBudgetRequest) (*pb.MessageResponsee, error)
-	DeleteBudget(req *pb.DeleteBudgetRequest) (*pb.BudgetDeleteResponse, error)
-	UpdateBudgetAmount(UserId string, amount float32) error
-	CheckBudget(userId string) (bool, error)
+	CreateBudget(ctx context.Context, req *pb.CreateBudgetRequest) (*pb.MessageResponsee, error)
+	ListBudgets(ctx context.Context, req *pb.ListBudgetsRequest) (*pb.ListBudgetsResponse, error)
+	GetBudgetById(ctx context.Context, req *pb.GetBudgetByIdRequest) (*pb.BudgetResponse, error)
+	UpdateBudget(ctx context.Context, req *pb.UpdateBudgetRequest) (*pb.MessageResponsee, error)
+	DeleteBudget(ctx context.Context, req *pb.DeleteBudgetRequest) (*pb.BudgetDeleteResponse, error)
+	UpdateBudgetAmount(ctx context.Context, UserId string, amount float32) error
+	CheckBudget(ctx context.Context, userId string, spend float32) (bool, error)
+	RestoreBudget(ctx context.Context, budgetID string) error
+	SetHousehold(ctx context.Context, budgetID, householdID string) error
+	CountActive(ctx context.Context, now time.Time) (active, overLimit int64, err error)
 }
 
 type CategoryStorage interface {
-	CreateCategory(req *pb.CreateCategoryRequest) (*pb.MessageResponse, error)
-	ListCategories(req *pb.ListCategoriesRequest) (*pb.ListResponse, error)
-	GetCategoryById(req *pb.GetCategoryByIdRequest) (*pb.CategoryResponse, error)
-	UpdateCategory(req *pb.UpdateCategoryRequest) (*pb.MessageResponse, error)
-	DeleteCategory(req *pb.DeleteCategoryRequest) (*pb.CategoryDeleteResponse, error)
+	CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.MessageResponse, error)
+	ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListResponse, error)
+	GetCategoryById(ctx context.Context, req *pb.GetCategoryByIdRequest) (*pb.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.MessageResponse, error)
+	DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.CategoryDeleteResponse, error)
+	ListCategoryTemplates(ctx context.Context, req *pb.ListCategoryTemplatesRequest) (*pb.ListCategoryTemplatesResponse, error)
+	SeedDefaultCategories(ctx context.Context, req *pb.SeedDefaultCategoriesRequest) (*pb.SeedDefaultCategoriesResponse, error)
+	RestoreCategory(ctx context.Context, categoryID string) error
 }
 
 type GoalStorage interface {
-	CreateGoal(req *pb.CreateGoalRequest) (*pb.Responsee, error)
-	ListGoals(req *pb.ListGoalsRequest) (*pb.ListGoalsResponse, error)
-	GetGoalById(req *pb.GetGoalByIdRequest) (*pb.GoalResponse, error)
-	UpdateGoal(req *pb.UpdateGoalRequest) (*pb.Responsee, error)
-	DeleteGoal(req *pb.DeleteGoalRequest) (*pb.GoalDeleteResponse, error)
+	CreateGoal(ctx context.Context, req *pb.CreateGoalRequest) (*pb.Responsee, error)
+	ListGoals(ctx context.Context, req *pb.ListGoalsRequest) (*pb.ListGoalsResponse, error)
+	GetGoalById(ctx context.Context, req *pb.GetGoalByIdRequest) (*pb.GoalResponse, error)
+	UpdateGoal(ctx context.Context, req *pb.UpdateGoalRequest) (*pb.Responsee, error)
+	DeleteGoal(ctx context.Context, req *pb.DeleteGoalRequest) (*pb.GoalDeleteResponse, error)
 	UpdateGoalAmount(ctx context.Context, UserId string, amount float32) error
 	CheckGoal(ctx context.Context, userId string) (bool, string, error)
+	RestoreGoal(ctx context.Context, goalID string) error
 }
 
 type TransactionStorage interface {
-	CreateTransaction(req *pb.CreateTransactionRequest) (*pb.Response, error)
-	GetTransactions(req *pb.GetTransactionsRequest) (*pb.TransactionsResponse, error)
-	GetTransactionById(req *pb.GetTransactionByIdRequest) (*pb.TransactionResponse, error)
-	UpdateTransaction(req *pb.UpdateTransactionRequest) (*pb.Response, error)
-	DeleteTransaction(req *pb.DeleteTransactionRequest) (*pb.TransactionDeleteResponse, error)
+	CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.Response, error)
+	GetTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (*pb.TransactionsResponse, error)
+	GetTransactionById(ctx context.Context, req *pb.GetTransactionByIdRequest) (*pb.TransactionResponse, error)
+	UpdateTransaction(ctx context.Context, req *pb.UpdateTransactionRequest) (*pb.Response, error)
+	DeleteTransaction(ctx context.Context, req *pb.DeleteTransactionRequest) (*pb.TransactionDeleteResponse, error)
+	SetCleared(ctx context.Context, accountId string, transactionIds []string, cleared bool) (int64, error)
+	MarkReconciled(ctx context.Context, accountId string, transactionIds []string) error
+	RestoreTransaction(ctx context.Context, transactionID string) error
 }
 
 type NotificationService interface {
-	CreateNotification(req model.Send) error
-	GetNotification(req *pb.GetNotificationByidRequest) (*pb.GetNotificationByidResponse, error)
-	DeleteNotification(req *pb.GetNotificationByidRequest) (*pb.NotificationsResponse, error)
-	ListNotification(req *pb.Void) (*pb.ListNotificationResponse, error)
+	CreateNotification(ctx context.Context, req model.Send) error
+	GetNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.GetNotificationByidResponse, error)
+	DeleteNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.NotificationsResponse, error)
+	ListNotification(ctx context.Context, req *pb.Void) (*pb.ListNotificationResponse, error)
+	RestoreNotification(ctx context.Context, notificationID string) error
+}
+
+type ExchangeRateStorage interface {
+	SetExchangeRate(ctx context.Context, req *pb.ExchangeRate) (*pb.ExchangeRateResponse, error)
+	ListExchangeRates(ctx context.Context, req *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error)
+	GetRate(ctx context.Context, from, to, date string) (float64, error)
+	LoadExchangeRates(ctx context.Context, path string) (int, error)
+}
+
+type NetWorthStorage interface {
+	SaveSnapshot(ctx context.Context, snapshot *pb.NetWorthSnapshot) error
+	GetNetWorthHistory(ctx context.Context, req *pb.GetNetWorthHistoryRequest) (*pb.NetWorthHistoryResponse, error)
+}
+
+type ReconciliationStorage interface {
+	CreateReconciliation(ctx context.Context, rec *pb.Reconciliation) error
+	ListReconciliations(ctx context.Context, req *pb.ListReconciliationsRequest) (*pb.ListReconciliationsResponse, error)
+}
+
+type TrashStorage interface {
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AuditLogStorage interface {
+	AppendAuditEvent(ctx context.Context, event *pb.AuditEvent) error
+	ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error)
+}
+
+type UserSettingsStorage interface {
+	GetUserSettings(ctx context.Context, userId string) (*pb.UserSettings, error)
+	UpdateUserSettings(ctx context.Context, req *pb.UpdateUserSettingsRequest) (*pb.UserSettings, error)
+}
+
+type HouseholdStorage interface {
+	CreateHousehold(ctx context.Context, name, ownerID string) (*pb.Household, error)
+	GetHouseholdById(ctx context.Context, householdID string) (*pb.Household, error)
+	ListHouseholds(ctx context.Context, userID string) (*pb.ListHouseholdsResponse, error)
+	UpdateHousehold(ctx context.Context, household *pb.Household) error
+	DeleteHousehold(ctx context.Context, householdID string) error
+}
+
+type SplitStorage interface {
+	CreateSplit(ctx context.Context, split *pb.SplitExpense) error
+	ListSplits(ctx context.Context, userID, householdID string) ([]*pb.SplitExpense, error)
+	CreateSettlement(ctx context.Context, settlement *pb.Settlement) error
+	ListSettlements(ctx context.Context, userID, householdID string) ([]*pb.Settlement, error)
 }
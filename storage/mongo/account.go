@@ -2,12 +2,13 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"log"
+	"time"
 
+	"budget-service/appctx"
+	"budget-service/apperr"
 	pb "budget-service/genproto"
 
-	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,16 +22,74 @@ func NewAccountStorage(db *mongo.Database) *AccountStorage {
 	return &AccountStorage{db: db}
 }
 
-func (s *AccountStorage) CreateAccount(req *pb.CreateAccountRequest) (*pb.CreateAccountRes, error) {
+// accountDocument is how an account is stored in the accounts collection.
+// Credit cards and loans keep the amount owed in Balance.
+type accountDocument struct {
+	ID                  primitive.ObjectID `bson:"_id"`
+	UserID              string             `bson:"user_id"`
+	AccountName         string             `bson:"account_name"`
+	Type                string             `bson:"type"`
+	Balance             float64            `bson:"balance"`
+	Currency            string             `bson:"currency"`
+	OpeningBalance      float64            `bson:"opening_balance"`
+	CreditLimit         float64            `bson:"credit_limit"`
+	StatementClosingDay int32              `bson:"statement_closing_day"`
+	PaymentDueDay       int32              `bson:"payment_due_day"`
+	APR                 float64            `bson:"apr"`
+	MinimumPayment      float64            `bson:"minimum_payment"`
+	Archived            bool               `bson:"archived"`
+	ArchivedAt          string             `bson:"archived_at"`
+	HouseholdID         string             `bson:"household_id"`
+	Version             int64              `bson:"version"`
+}
+
+func (a accountDocument) toProto() *pb.AccountResponse {
+	account := &pb.AccountResponse{
+		AccountId:           a.ID.Hex(),
+		UserId:              a.UserID,
+		AccountName:         a.AccountName,
+		AccountType:         a.Type,
+		Balance:             a.Balance,
+		Currency:            a.Currency,
+		OpeningBalance:      a.OpeningBalance,
+		CreditLimit:         a.CreditLimit,
+		StatementClosingDay: a.StatementClosingDay,
+		PaymentDueDay:       a.PaymentDueDay,
+		Apr:                 a.APR,
+		MinimumPayment:      a.MinimumPayment,
+		Archived:            a.Archived,
+		ArchivedAt:          a.ArchivedAt,
+		HouseholdId:         a.HouseholdID,
+		Version:             a.Version,
+	}
+	if a.CreditLimit > 0 {
+		account.AvailableCredit = a.CreditLimit - a.Balance
+	}
+	return account
+}
+
+func (s *AccountStorage) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountRes, error) {
 	coll := s.db.Collection("accounts")
-	id := uuid.NewString()
-	_, err := coll.InsertOne(context.Background(), bson.M{
-		"id":           id,
-		"user_id":      req.UserId,
-		"account_name": req.AccountName,
-		"type":         req.Type,
-		"balance":      req.Balance,
-		"currency":     req.Currency,
+
+	// Generate a new ObjectID for the account
+	objID := primitive.NewObjectID()
+	req.Id = objID.Hex() // Set the ID field in the request
+
+	_, err := coll.InsertOne(ctx, bson.M{
+		"_id":                   objID,
+		"user_id":               req.UserId,
+		"account_name":          req.AccountName,
+		"type":                  req.Type,
+		"balance":               req.Balance,
+		"opening_balance":       req.Balance,
+		"currency":              req.Currency,
+		"credit_limit":          req.CreditLimit,
+		"statement_closing_day": req.StatementClosingDay,
+		"payment_due_day":       req.PaymentDueDay,
+		"apr":                   req.Apr,
+		"minimum_payment":       req.MinimumPayment,
+		"household_id":          req.HouseholdId,
+		"version":               1,
 	})
 	if err != nil {
 		log.Printf("Failed to create account: %v", err)
@@ -42,53 +101,43 @@ func (s *AccountStorage) CreateAccount(req *pb.CreateAccountRequest) (*pb.Create
 	return &pb.CreateAccountRes{Message: "Account created successfully"}, nil
 }
 
-func (s *AccountStorage) GetAccountById(req *pb.GetAccountByIdRequest) (*pb.AccountResponse, error) {
+func (s *AccountStorage) GetAccountById(ctx context.Context, req *pb.GetAccountByIdRequest) (*pb.AccountResponse, error) {
 	coll := s.db.Collection("accounts")
 	objID, err := primitive.ObjectIDFromHex(req.AccountId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid budget ID: %v", err)
+		return nil, apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
 	}
 
-	var accountData struct {
-		ID          primitive.ObjectID `bson:"_id"`
-		UserID      string             `bson:"user_id"`
-		AccountName string             `bson:"account_name"`
-		Type        string             `bson:"type"`
-		Balance     float64            `bson:"balance"`
-		Currency    string             `bson:"currency"`
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, false)
+	if err != nil {
+		return nil, err
 	}
 
-	err = coll.FindOne(context.Background(), bson.M{"_id": objID}).Decode(&accountData)
+	var accountData accountDocument
+	err = coll.FindOne(ctx, notDeleted(filter)).Decode(&accountData)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("budget not found")
+			return nil, apperr.NotFound("account", req.AccountId)
 		}
 		log.Printf("Failed to get account by ID: %v", err)
 		return nil, err
 	}
 
-	account := &pb.AccountResponse{
-		AccountId:   accountData.ID.Hex(),
-		UserId:      accountData.UserID,
-		AccountName: accountData.AccountName,
-		AccountType: accountData.Type,
-		Balance:     accountData.Balance,
-		Currency:    accountData.Currency,
-	}
-
-	return account, nil
+	return accountData.toProto(), nil
 }
-func (s *AccountStorage) UpdateAccount(req *pb.UpdateAccountRequest) (*pb.CreateAccountRes, error) {
+func (s *AccountStorage) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.CreateAccountRes, error) {
 	coll := s.db.Collection("accounts")
 
 	objID, err := primitive.ObjectIDFromHex(req.AccountId)
 	if err != nil {
-		return &pb.CreateAccountRes{Message: "Invalid account ID"}, err
+		return &pb.CreateAccountRes{Message: "Invalid account ID"}, apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
 	}
 
 	// Prepare the update fields
 	updateFields := bson.M{}
-	if req.UserId != "" {
+	// Household editors can update a shared item but not take it over, so only
+	// calls that aren't limited to one user may hand it to someone else
+	if req.UserId != "" && appctx.UserID(ctx) == "" {
 		updateFields["user_id"] = req.UserId
 	}
 	if req.AccountName != "" {
@@ -97,20 +146,47 @@ func (s *AccountStorage) UpdateAccount(req *pb.UpdateAccountRequest) (*pb.Create
 	if req.Type != "" {
 		updateFields["type"] = req.Type
 	}
-	if req.Balance != 0 {
-		updateFields["balance"] = req.Balance
-	}
 	if req.Currency != "" {
 		updateFields["currency"] = req.Currency
 	}
+	if req.CreditLimit != 0 {
+		updateFields["credit_limit"] = req.CreditLimit
+	}
+	if req.StatementClosingDay != 0 {
+		updateFields["statement_closing_day"] = req.StatementClosingDay
+	}
+	if req.PaymentDueDay != 0 {
+		updateFields["payment_due_day"] = req.PaymentDueDay
+	}
+	if req.Apr != 0 {
+		updateFields["apr"] = req.Apr
+	}
+	if req.MinimumPayment != 0 {
+		updateFields["minimum_payment"] = req.MinimumPayment
+	}
+
+	// The balance follows the transactions from the opening balance, so it moves
+	// by as much as the opening balance does and can't be set on its own
+	update := bson.M{}
+	if req.OpeningBalance != 0 {
+		updateFields["opening_balance"] = req.OpeningBalance
+		delta, err := s.openingBalanceChange(ctx, objID, req.Version, req.OpeningBalance)
+		if err != nil {
+			log.Printf("Failed to update account: %v", err)
+			return &pb.CreateAccountRes{Message: "Failed to update account"}, err
+		}
+		if delta != 0 {
+			update["$inc"] = bson.M{"balance": delta}
+		}
+	}
 
 	// If no fields to update, return an appropriate message
 	if len(updateFields) == 0 {
 		return &pb.CreateAccountRes{Message: "No fields to update"}, nil
 	}
+	update["$set"] = updateFields
 
-	update := bson.M{"$set": updateFields}
-	result, err := coll.UpdateOne(context.Background(), bson.M{"_id": objID}, update)
+	err = updateVersionedIf(ctx, coll, objID, req.Version, nil, nil, update, "account")
 	if err != nil {
 		log.Printf("Failed to update account: %v", err)
 		return &pb.CreateAccountRes{
@@ -118,76 +194,153 @@ func (s *AccountStorage) UpdateAccount(req *pb.UpdateAccountRequest) (*pb.Create
 		}, err
 	}
 
-	// Check if any document was modified
-	if result.ModifiedCount == 0 {
-		return &pb.CreateAccountRes{Message: "No account found with the provided account_id"}, nil
-	}
-
 	return &pb.CreateAccountRes{Message: "Account updated successfully"}, nil
 }
 
-func (s *AccountStorage) DeleteAccount(req *pb.DeleteAccountRequest) (*pb.DeleteResponse, error) {
+// openingBalanceChange is how much openingBalance differs from the account's at
+// the given version. It is zero when the account isn't at that version, which
+// the versioned update then reports.
+func (s *AccountStorage) openingBalanceChange(ctx context.Context, objID primitive.ObjectID, version int64, openingBalance float64) (float64, error) {
 	coll := s.db.Collection("accounts")
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID, "version": version}, true)
+	if err != nil {
+		return 0, err
+	}
+
+	var current struct {
+		OpeningBalance float64 `bson:"opening_balance"`
+	}
+	err = coll.FindOne(ctx, notDeleted(filter)).Decode(&current)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return openingBalance - current.OpeningBalance, nil
+}
+
+// DeleteAccount moves the account and its transactions to the trash.
+// Closing an account that should stay in reports is done with SetArchived.
+func (s *AccountStorage) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteResponse, error) {
 	objID, err := primitive.ObjectIDFromHex(req.AccountId)
 	if err != nil {
-		return &pb.DeleteResponse{
-			Success: false,
-		}, fmt.Errorf("invalid account ID: %v", err)
+		return &pb.DeleteResponse{Success: false}, apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
 	}
 
-	_, err = coll.DeleteOne(context.Background(), bson.M{"_id": objID})
+	// The transactions share the account's deleted_at so restoring it brings back exactly these
+	deletedAt := time.Now()
+	deleted, err := softDelete(ctx, s.db.Collection("accounts"), bson.M{"_id": objID}, deletedAt)
 	if err != nil {
 		log.Printf("Failed to delete account: %v", err)
-		return &pb.DeleteResponse{
-			Success: false,
-		}, err
+		return &pb.DeleteResponse{Success: false}, err
+	}
+	if deleted == 0 {
+		return &pb.DeleteResponse{Success: false}, apperr.NotFound("account", req.AccountId)
+	}
+
+	_, err = softDelete(ctx, s.db.Collection("transactions"), bson.M{"account_id": req.AccountId}, deletedAt)
+	if err != nil {
+		log.Printf("Failed to delete account transactions: %v", err)
+		return &pb.DeleteResponse{Success: false}, err
 	}
 
 	return &pb.DeleteResponse{Success: true}, nil
 }
 
-func (s *AccountStorage) ListAccounts(req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+// RestoreAccount takes an account out of the trash together with the transactions deleted with it
+func (s *AccountStorage) RestoreAccount(ctx context.Context, accountID string) error {
+	deletedAt, err := restore(ctx, s.db.Collection("accounts"), accountID)
+	if err != nil {
+		log.Printf("Failed to restore account: %v", err)
+		return err
+	}
+
+	filter, err := scoped(ctx, s.db.Collection("transactions"), bson.M{"account_id": accountID, "deleted_at": deletedAt}, true)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$unset": bson.M{"deleted_at": ""}, "$inc": bumpVersion}
+	_, err = s.db.Collection("transactions").UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Printf("Failed to restore account transactions: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SetArchived closes or reopens an account
+func (s *AccountStorage) SetArchived(ctx context.Context, accountID string, archived bool) error {
+	coll := s.db.Collection("accounts")
+	objID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+	}
+
+	update := bson.M{"$set": bson.M{"archived": true, "archived_at": time.Now().Format(time.RFC3339)}, "$inc": bumpVersion}
+	if !archived {
+		update = bson.M{"$set": bson.M{"archived": false}, "$unset": bson.M{"archived_at": ""}, "$inc": bumpVersion}
+	}
+
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, true)
+	if err != nil {
+		return err
+	}
+	result, err := coll.UpdateOne(ctx, notDeleted(filter), update)
+	if err != nil {
+		log.Printf("Failed to archive account: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("account", accountID)
+	}
+	return nil
+}
+
+func (s *AccountStorage) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
 	coll := s.db.Collection("accounts")
 	filter := bson.M{}
 
+	// A user's accounts include those shared with their households
 	if req.UserId != "" {
-		filter["user_id"] = req.UserId
+		reach, err := reachable(ctx, s.db, req.UserId, false)
+		if err != nil {
+			log.Printf("Failed to list accounts: %v", err)
+			return nil, err
+		}
+		filter = and(filter, reach)
+	}
+	if req.HouseholdId != "" {
+		filter["household_id"] = req.HouseholdId
+	}
+	if req.AccountType != "" {
+		filter["type"] = req.AccountType
+	}
+	if !req.IncludeArchived {
+		filter["archived"] = bson.M{"$ne": true}
 	}
 	// ... other filter conditions ...
 
-	cursor, err := coll.Find(context.Background(), filter)
+	filter, err := scoped(ctx, coll, filter, false)
+	if err != nil {
+		log.Printf("Failed to list accounts: %v", err)
+		return nil, err
+	}
+	cursor, err := coll.Find(ctx, notDeleted(filter))
 	if err != nil {
 		log.Printf("Failed to list accounts: %v", err)
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(ctx)
 
 	var accounts []*pb.AccountResponse
-	for cursor.Next(context.Background()) {
-		// Use a temporary struct for decoding
-		var accountData struct {
-			ID          primitive.ObjectID `bson:"_id"` // BSON tag for ID field
-			UserID      string             `bson:"user_id"`
-			AccountName string             `bson:"account_name"`
-			Type        string             `bson:"type"`
-			Balance     float64            `bson:"balance"`
-			Currency    string             `bson:"currency"`
-		}
+	for cursor.Next(ctx) {
+		var accountData accountDocument
 		if err := cursor.Decode(&accountData); err != nil {
 			log.Printf("Failed to decode account: %v", err)
 			return nil, err
 		}
-
-		// Convert temporary struct data to Protobuf message
-		account := &pb.AccountResponse{
-			AccountId:   accountData.ID.Hex(),
-			UserId:      accountData.UserID,
-			AccountName: accountData.AccountName,
-			AccountType: accountData.Type,
-			Balance:     accountData.Balance,
-			Currency:    accountData.Currency,
-		}
-		accounts = append(accounts, account)
+		accounts = append(accounts, accountData.toProto())
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -204,14 +357,28 @@ func (s *AccountStorage) UpdateBalance(ctx context.Context, accountID string, am
 	update := bson.M{
 		"$inc": bson.M{
 			"balance": amount,
+			"version": 1,
 		},
 	}
 
-	_, err := coll.UpdateOne(ctx, bson.M{"id": accountID}, update)
+	objID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+	}
+
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, true)
+	if err != nil {
+		return err
+	}
+	result, err := coll.UpdateOne(ctx, notDeleted(filter), update)
 	if err != nil {
 		log.Printf("Failed to update account balance: %v", err)
 		return err
 	}
+
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("account", accountID)
+	}
 	return nil
 }
 
@@ -222,11 +389,21 @@ func (s *AccountStorage) UpdateBalanceMinus(ctx context.Context, accountID strin
 	update := bson.M{
 		"$inc": bson.M{
 			"balance": -amount,
+			"version": 1,
 		},
 	}
 
+	objID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+	}
+
 	// Perform the update operation
-	result, err := coll.UpdateOne(ctx, bson.M{"id": accountID}, update)
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, true)
+	if err != nil {
+		return err
+	}
+	result, err := coll.UpdateOne(ctx, notDeleted(filter), update)
 	if err != nil {
 		log.Printf("Failed to update account balance: %v", err)
 		return err
@@ -234,10 +411,102 @@ func (s *AccountStorage) UpdateBalanceMinus(ctx context.Context, accountID strin
 
 	// Check if any document was matched by the query
 	if result.MatchedCount == 0 {
-		err = fmt.Errorf("no account found with ID %s", accountID)
+		err = apperr.NotFound("account", accountID)
 		log.Printf("Failed to update account balance: %v", err)
 		return err
 	}
 
 	return nil
 }
+
+// BackfillOpeningBalances gives accounts created before opening balances were
+// recorded their stored balance as the opening one. Incremental balance updates
+// never matched those documents, so the balance is still the one they were opened with.
+func BackfillOpeningBalances(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection("accounts")
+
+	filter := bson.M{"opening_balance": bson.M{"$exists": false}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"opening_balance": "$balance"}}}}
+	result, err := coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		log.Printf("Failed to backfill opening balances: %v", err)
+		return err
+	}
+	if result.ModifiedCount > 0 {
+		log.Printf("Backfilled opening balance for %d accounts", result.ModifiedCount)
+	}
+	return nil
+}
+
+// SetBalance overwrites the stored balance, used when rebuilding it from the ledger
+func (s *AccountStorage) SetBalance(ctx context.Context, accountID string, balance float64) error {
+	coll := s.db.Collection("accounts")
+
+	objID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+	}
+
+	update := bson.M{"$set": bson.M{"balance": balance}, "$inc": bumpVersion}
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, true)
+	if err != nil {
+		return err
+	}
+	result, err := coll.UpdateOne(ctx, notDeleted(filter), update)
+	if err != nil {
+		log.Printf("Failed to set account balance: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("account", accountID)
+	}
+	return nil
+}
+
+// SetHousehold shares the account and its transactions with a household, or makes
+// them private again when householdID is empty. Only the account's owner may do so.
+func (s *AccountStorage) SetHousehold(ctx context.Context, accountID, householdID string) error {
+	coll := s.db.Collection("accounts")
+
+	objID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+	}
+
+	update := bson.M{"$set": bson.M{"household_id": householdID}, "$inc": bumpVersion}
+	result, err := coll.UpdateOne(ctx, notDeleted(owned(ctx, bson.M{"_id": objID})), update)
+	if err != nil {
+		log.Printf("Failed to share account: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("account", accountID)
+	}
+
+	// Trashed transactions follow too, so restoring them keeps the account's sharing
+	_, err = s.db.Collection("transactions").UpdateMany(ctx, bson.M{"account_id": accountID}, update)
+	if err != nil {
+		log.Printf("Failed to share account transactions: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SetRemindedDue records the payment due date (YYYY-MM-DD) the owner was last
+// reminded of, and reports whether it changed. An empty dueDate clears it.
+func (s *AccountStorage) SetRemindedDue(ctx context.Context, accountID, dueDate string) (bool, error) {
+	coll := s.db.Collection("accounts")
+	objID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return false, apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+	}
+
+	// Bookkeeping only, so the version stays and clients' edits don't conflict
+	filter := owned(ctx, bson.M{"_id": objID, "reminded_due": bson.M{"$ne": dueDate}})
+	result, err := coll.UpdateOne(ctx, notDeleted(filter), bson.M{"$set": bson.M{"reminded_due": dueDate}})
+	if err != nil {
+		log.Printf("Failed to record due date reminder: %v", err)
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	pb "budget-service/genproto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditLogStorage keeps the append-only audit_log collection.
+// Events are only ever inserted; there is no update or delete.
+type AuditLogStorage struct {
+	db *mongo.Database
+}
+
+// NewAuditLogStorage initializes a new AuditLogStorage
+func NewAuditLogStorage(db *mongo.Database) *AuditLogStorage {
+	return &AuditLogStorage{db: db}
+}
+
+type auditEventData struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Entity    string             `bson:"entity"`
+	EntityId  string             `bson:"entity_id"`
+	Operation string             `bson:"operation"`
+	Actor     string             `bson:"actor"`
+	UserId    string             `bson:"user_id"`
+	RequestId string             `bson:"request_id"`
+	Before    string             `bson:"before"`
+	After     string             `bson:"after"`
+	CreatedAt string             `bson:"created_at"`
+}
+
+// AppendAuditEvent stores the event and sets its generated ID
+func (s *AuditLogStorage) AppendAuditEvent(ctx context.Context, event *pb.AuditEvent) error {
+	coll := s.db.Collection("audit_log")
+
+	objID := primitive.NewObjectID()
+	_, err := coll.InsertOne(ctx, auditEventData{
+		ID:        objID,
+		Entity:    event.Entity,
+		EntityId:  event.EntityId,
+		Operation: event.Operation,
+		Actor:     event.Actor,
+		UserId:    event.UserId,
+		RequestId: event.RequestId,
+		Before:    event.Before,
+		After:     event.After,
+		CreatedAt: event.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("Failed to append audit event: %v", err)
+		return err
+	}
+
+	event.EventId = objID.Hex()
+	return nil
+}
+
+// ListAuditEvents lists events newest first, filtered by entity, user and actor
+func (s *AuditLogStorage) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	coll := s.db.Collection("audit_log")
+
+	filter := bson.M{}
+	if req.Entity != "" {
+		filter["entity"] = req.Entity
+	}
+	if req.EntityId != "" {
+		filter["entity_id"] = req.EntityId
+	}
+	if req.UserId != "" {
+		filter["user_id"] = req.UserId
+	}
+	if req.Actor != "" {
+		filter["actor"] = req.Actor
+	}
+
+	// ObjectIDs grow with insertion time, so sorting on _id keeps events in the order they happened
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	if req.Limit > 0 {
+		opts.SetLimit(req.Limit)
+	}
+	cursor, err := coll.Find(ctx, owned(ctx, filter), opts)
+	if err != nil {
+		log.Printf("Failed to list audit events: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*pb.AuditEvent
+	for cursor.Next(ctx) {
+		var data auditEventData
+		if err := cursor.Decode(&data); err != nil {
+			log.Printf("Failed to decode audit event: %v", err)
+			return nil, err
+		}
+		events = append(events, &pb.AuditEvent{
+			EventId:   data.ID.Hex(),
+			Entity:    data.Entity,
+			EntityId:  data.EntityId,
+			Operation: data.Operation,
+			Actor:     data.Actor,
+			UserId:    data.UserId,
+			RequestId: data.RequestId,
+			Before:    data.Before,
+			After:     data.After,
+			CreatedAt: data.CreatedAt,
+		})
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+
+	return &pb.ListAuditEventsResponse{Events: events}, nil
+}
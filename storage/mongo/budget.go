@@ -2,10 +2,11 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
+	"budget-service/appctx"
+	"budget-service/apperr"
 	pb "budget-service/genproto"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -24,21 +25,24 @@ func NewBudgetStorage(db *mongo.Database) *BudgetStorage {
 }
 
 // CreateBudget creates a new budget in the database
-func (s *BudgetStorage) CreateBudget(req *pb.CreateBudgetRequest) (*pb.MessageResponsee, error) {
+func (s *BudgetStorage) CreateBudget(ctx context.Context, req *pb.CreateBudgetRequest) (*pb.MessageResponsee, error) {
 	coll := s.db.Collection("budgets")
 
 	// Generate a new ObjectID for the budget
 	objID := primitive.NewObjectID()
 	req.Id = objID.Hex() // Set the ID field in the request
 
-	_, err := coll.InsertOne(context.Background(), bson.M{
-		"_id":         objID, // Use ObjectID for _id
-		"user_id":     req.UserId,
-		"category_id": req.CategoryId,
-		"amount":      req.Amount,
-		"period":      req.Period,
-		"start_date":  req.StartDate,
-		"end_date":    req.EndDate,
+	_, err := coll.InsertOne(ctx, bson.M{
+		"_id":          objID, // Use ObjectID for _id
+		"user_id":      req.UserId,
+		"category_id":  req.CategoryId,
+		"amount":       req.Amount,
+		"period":       req.Period,
+		"start_date":   asTime(req.StartDate),
+		"end_date":     asTime(req.EndDate),
+		"time_zone":    timeZoneOrUTC(req.TimeZone),
+		"household_id": req.HouseholdId,
+		"version":      1,
 	})
 	if err != nil {
 		log.Printf("Failed to create budget: %v", err)
@@ -49,7 +53,7 @@ func (s *BudgetStorage) CreateBudget(req *pb.CreateBudgetRequest) (*pb.MessageRe
 }
 
 // ListBudgets lists all budgets, potentially filtering by start_date and end_date
-func (s *BudgetStorage) ListBudgets(req *pb.ListBudgetsRequest) (*pb.ListBudgetsResponse, error) {
+func (s *BudgetStorage) ListBudgets(ctx context.Context, req *pb.ListBudgetsRequest) (*pb.ListBudgetsResponse, error) {
 	coll := s.db.Collection("budgets")
 
 	filter := bson.M{}
@@ -58,34 +62,59 @@ func (s *BudgetStorage) ListBudgets(req *pb.ListBudgetsRequest) (*pb.ListBudgets
 	if req.BudgetId != "" {
 		objID, err := primitive.ObjectIDFromHex(req.BudgetId)
 		if err != nil {
-			return nil, fmt.Errorf("invalid budget ID: %v", err)
+			return nil, apperr.InvalidArgument("budget_id", "invalid budget ID: %v", err)
 		}
 		filter["_id"] = objID
 	}
+	// A user's budgets include those shared with their households
+	if req.UserId != "" {
+		reach, err := reachable(ctx, s.db, req.UserId, false)
+		if err != nil {
+			log.Printf("Failed to list budgets: %v", err)
+			return nil, err
+		}
+		filter = and(filter, reach)
+	}
+	if req.HouseholdId != "" {
+		filter["household_id"] = req.HouseholdId
+	}
+	if req.CategoryId != "" {
+		filter["category_id"] = req.CategoryId
+	}
 
 	// Filter by start_date and end_date
-	if req.StartDate != "" && req.EndDate != "" {
-		filter["start_date"] = bson.M{"$gte": req.StartDate}
-		filter["end_date"] = bson.M{"$lte": req.EndDate}
+	if req.StartDate != nil {
+		filter["start_date"] = bson.M{"$gte": req.StartDate.AsTime()}
+	}
+	if req.EndDate != nil {
+		filter["end_date"] = bson.M{"$lte": req.EndDate.AsTime()}
 	}
 
-	cursor, err := coll.Find(context.Background(), filter)
+	filter, err := scoped(ctx, coll, filter, false)
+	if err != nil {
+		log.Printf("Failed to list budgets: %v", err)
+		return nil, err
+	}
+	cursor, err := coll.Find(ctx, notDeleted(filter))
 	if err != nil {
 		log.Printf("Failed to list budgets: %v", err)
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(ctx)
 
 	var budgets []*pb.BudgetResponse
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var budgetData struct {
 			ID          primitive.ObjectID `bson:"_id"` // BSON tag for ID field
 			UserID      string             `bson:"user_id"`
 			CategoryID  string             `bson:"category_id"`
 			Amount      float64            `bson:"amount"`
 			Period      string             `bson:"period"`
-			StartDate  string             `bson:"start_date"`
-			EndDate    string             `bson:"end_date"`
+			StartDate   time.Time          `bson:"start_date"`
+			EndDate     time.Time          `bson:"end_date"`
+			TimeZone    string             `bson:"time_zone"`
+			HouseholdID string             `bson:"household_id"`
+			Version     int64              `bson:"version"`
 		}
 		if err := cursor.Decode(&budgetData); err != nil {
 			log.Printf("Failed to decode budget: %v", err)
@@ -99,8 +128,11 @@ func (s *BudgetStorage) ListBudgets(req *pb.ListBudgetsRequest) (*pb.ListBudgets
 			CategoryId:  budgetData.CategoryID,
 			Amount:      budgetData.Amount,
 			Period:      budgetData.Period,
-			StartDate:  budgetData.StartDate,
-			EndDate:    budgetData.EndDate,
+			StartDate:   timestamp(budgetData.StartDate),
+			EndDate:     timestamp(budgetData.EndDate),
+			TimeZone:    budgetData.TimeZone,
+			HouseholdId: budgetData.HouseholdID,
+			Version:     budgetData.Version,
 		}
 		budgets = append(budgets, budget)
 	}
@@ -114,12 +146,12 @@ func (s *BudgetStorage) ListBudgets(req *pb.ListBudgetsRequest) (*pb.ListBudgets
 }
 
 // GetBudgetById retrieves a budget by its ID
-func (s *BudgetStorage) GetBudgetById(req *pb.GetBudgetByIdRequest) (*pb.BudgetResponse, error) {
+func (s *BudgetStorage) GetBudgetById(ctx context.Context, req *pb.GetBudgetByIdRequest) (*pb.BudgetResponse, error) {
 	coll := s.db.Collection("budgets")
 
 	objID, err := primitive.ObjectIDFromHex(req.BudgetId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid budget ID: %v", err)
+		return nil, apperr.InvalidArgument("budget_id", "invalid budget ID: %v", err)
 	}
 
 	var budgetData struct {
@@ -128,14 +160,21 @@ func (s *BudgetStorage) GetBudgetById(req *pb.GetBudgetByIdRequest) (*pb.BudgetR
 		CategoryID  string             `bson:"category_id"`
 		Amount      float64            `bson:"amount"`
 		Period      string             `bson:"period"`
-		StartDate  string             `bson:"start_date"`
-		EndDate    string             `bson:"end_date"`
+		StartDate   time.Time          `bson:"start_date"`
+		EndDate     time.Time          `bson:"end_date"`
+		TimeZone    string             `bson:"time_zone"`
+		HouseholdID string             `bson:"household_id"`
+		Version     int64              `bson:"version"`
 	}
 
-	err = coll.FindOne(context.Background(), bson.M{"_id": objID}).Decode(&budgetData)
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, false)
+	if err != nil {
+		return nil, err
+	}
+	err = coll.FindOne(ctx, notDeleted(filter)).Decode(&budgetData)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("budget not found")
+			return nil, apperr.NotFound("budget", req.BudgetId)
 		}
 		log.Printf("Failed to get budget by ID: %v", err)
 		return nil, err
@@ -147,24 +186,29 @@ func (s *BudgetStorage) GetBudgetById(req *pb.GetBudgetByIdRequest) (*pb.BudgetR
 		CategoryId:  budgetData.CategoryID,
 		Amount:      budgetData.Amount,
 		Period:      budgetData.Period,
-		StartDate:  budgetData.StartDate,
-		EndDate:    budgetData.EndDate,
+		StartDate:   timestamp(budgetData.StartDate),
+		EndDate:     timestamp(budgetData.EndDate),
+		TimeZone:    budgetData.TimeZone,
+		HouseholdId: budgetData.HouseholdID,
+		Version:     budgetData.Version,
 	}
 
 	return budget, nil
 }
 
 // UpdateBudget updates a budget based on the provided request data
-func (s *BudgetStorage) UpdateBudget(req *pb.UpdateBudgetRequest) (*pb.MessageResponsee, error) {
+func (s *BudgetStorage) UpdateBudget(ctx context.Context, req *pb.UpdateBudgetRequest) (*pb.MessageResponsee, error) {
 	coll := s.db.Collection("budgets")
 
 	objID, err := primitive.ObjectIDFromHex(req.BudgetId)
 	if err != nil {
-		return &pb.MessageResponsee{Message: "Invalid budget ID"}, err
+		return &pb.MessageResponsee{Message: "Invalid budget ID"}, apperr.InvalidArgument("budget_id", "invalid budget ID: %v", err)
 	}
 
 	update := bson.M{}
-	if req.UserId != "" {
+	// Household editors can update a shared item but not take it over, so only
+	// calls that aren't limited to one user may hand it to someone else
+	if req.UserId != "" && appctx.UserID(ctx) == "" {
 		update["user_id"] = req.UserId
 	}
 	if req.CategoryId != "" {
@@ -176,18 +220,21 @@ func (s *BudgetStorage) UpdateBudget(req *pb.UpdateBudgetRequest) (*pb.MessageRe
 	if req.Period != "" {
 		update["period"] = req.Period
 	}
-	if req.StartDate != "" {
-		update["start_date"] = req.StartDate
+	if req.StartDate != nil {
+		update["start_date"] = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		update["end_date"] = req.EndDate.AsTime()
 	}
-	if req.EndDate != "" {
-		update["end_date"] = req.EndDate
+	if req.TimeZone != "" {
+		update["time_zone"] = req.TimeZone
 	}
 
 	if len(update) == 0 {
 		return &pb.MessageResponsee{Message: "Nothing to update"}, nil
 	}
 
-	_, err = coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{"$set": update})
+	err = updateVersioned(ctx, coll, objID, req.Version, update, "budget")
 	if err != nil {
 		log.Printf("Failed to update budget: %v", err)
 		return &pb.MessageResponsee{Message: "Failed to update budget"}, err
@@ -196,33 +243,89 @@ func (s *BudgetStorage) UpdateBudget(req *pb.UpdateBudgetRequest) (*pb.MessageRe
 	return &pb.MessageResponsee{Message: "Budget updated successfully"}, nil
 }
 
-// DeleteBudget deletes a budget by its ID
-func (s *BudgetStorage) DeleteBudget(req *pb.DeleteBudgetRequest) (*pb.BudgetDeleteResponse, error) {
+// DeleteBudget moves a budget to the trash
+func (s *BudgetStorage) DeleteBudget(ctx context.Context, req *pb.DeleteBudgetRequest) (*pb.BudgetDeleteResponse, error) {
 	coll := s.db.Collection("budgets")
 
 	objID, err := primitive.ObjectIDFromHex(req.BudgetId)
 	if err != nil {
-		return &pb.BudgetDeleteResponse{Success: false}, fmt.Errorf("invalid budget ID: %v", err)
+		return &pb.BudgetDeleteResponse{Success: false}, apperr.InvalidArgument("budget_id", "invalid budget ID: %v", err)
 	}
 
-	_, err = coll.DeleteOne(context.Background(), bson.M{"_id": objID})
+	deleted, err := softDelete(ctx, coll, bson.M{"_id": objID}, time.Now())
 	if err != nil {
 		log.Printf("Failed to delete budget: %v", err)
 		return &pb.BudgetDeleteResponse{Success: false}, err
 	}
+	if deleted == 0 {
+		return &pb.BudgetDeleteResponse{Success: false}, apperr.NotFound("budget", req.BudgetId)
+	}
 
 	return &pb.BudgetDeleteResponse{Success: true}, nil
 }
 
-func (s *BudgetStorage) UpdateBudgetAmount(userId string, amount float32) error {
+// RestoreBudget takes a deleted budget out of the trash
+func (s *BudgetStorage) RestoreBudget(ctx context.Context, budgetID string) error {
+	_, err := restore(ctx, s.db.Collection("budgets"), budgetID)
+	if err != nil {
+		log.Printf("Failed to restore budget: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SetHousehold shares the budget with a household, or makes it private again when
+// householdID is empty. Only the budget's owner may do so.
+func (s *BudgetStorage) SetHousehold(ctx context.Context, budgetID, householdID string) error {
+	coll := s.db.Collection("budgets")
+
+	objID, err := primitive.ObjectIDFromHex(budgetID)
+	if err != nil {
+		return apperr.InvalidArgument("budget_id", "invalid budget ID: %v", err)
+	}
+
+	update := bson.M{"$set": bson.M{"household_id": householdID}, "$inc": bumpVersion}
+	result, err := coll.UpdateOne(ctx, notDeleted(owned(ctx, bson.M{"_id": objID})), update)
+	if err != nil {
+		log.Printf("Failed to share budget: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("budget", budgetID)
+	}
+	return nil
+}
+
+// CountActive counts the budgets whose period includes now and, of those, the ones
+// whose remaining amount has gone below zero
+func (s *BudgetStorage) CountActive(ctx context.Context, now time.Time) (int64, int64, error) {
+	coll := s.db.Collection("budgets")
+
+	filter := bson.M{"start_date": bson.M{"$lte": now}, "end_date": bson.M{"$gte": now}}
+	active, err := coll.CountDocuments(ctx, notDeleted(owned(ctx, filter)))
+	if err != nil {
+		log.Printf("Failed to count budgets: %v", err)
+		return 0, 0, err
+	}
+	filter["amount"] = bson.M{"$lt": 0}
+	overLimit, err := coll.CountDocuments(ctx, notDeleted(owned(ctx, filter)))
+	if err != nil {
+		log.Printf("Failed to count budgets: %v", err)
+		return 0, 0, err
+	}
+	return active, overLimit, nil
+}
+
+func (s *BudgetStorage) UpdateBudgetAmount(ctx context.Context, userId string, amount float32) error {
 	coll := s.db.Collection("budgets")
 
 	update := bson.M{
 		"$inc": bson.M{
-			"amount": -amount,
+			"amount":  -amount,
+			"version": 1,
 		},
 	}
-	_, err := coll.UpdateOne(context.Background(), bson.M{"user_id": userId}, update)
+	_, err := coll.UpdateOne(ctx, notDeleted(owned(ctx, bson.M{"user_id": userId})), update)
 	if err != nil {
 		log.Printf("Failed to update account balance: %v", err)
 		return err
@@ -230,36 +333,39 @@ func (s *BudgetStorage) UpdateBudgetAmount(userId string, amount float32) error
 	return nil
 }
 
-func (s *BudgetStorage) CheckBudget(userId string) (bool, error) {
+// CheckBudget reports whether the user's budget still has money left after
+// spending spend. A user without a budget, or outside its period, has nothing
+// to deplete.
+func (s *BudgetStorage) CheckBudget(ctx context.Context, userId string, spend float32) (bool, error) {
 	coll := s.db.Collection("budgets")
 
 	// Define a struct to match the document structure
 	var result struct {
-		Amount    float32  `bson:"amount"`
-		StartDate string `bson:"start_date"`
-		EndDate   string `bson:"end_date"`
+		Amount    float32   `bson:"amount"`
+		StartDate time.Time `bson:"start_date"`
+		EndDate   time.Time `bson:"end_date"`
 	}
 
 	// Find the document for the given UserId
-	err := coll.FindOne(context.Background(), bson.M{"user_id": userId}).Decode(&result)
+	err := coll.FindOne(ctx, notDeleted(owned(ctx, bson.M{"user_id": userId}))).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return true, nil
+	}
 	if err != nil {
 		// Other errors (e.g., database issues)
 		log.Printf("Failed to get budget by UserId: %v", err)
 		return false, err
 	}
-	// Get the current date in the same string format as stored in MongoDB
-	now := time.Now().Format("2006-01-02")
-	
-	// Check if 'now' is between 'StartDate' and 'EndDate'
-	if now >= result.StartDate && now <= result.EndDate {
-		fmt.Println(result.EndDate)
+	now := time.Now()
 
-		// If within the date range, check if the amount is greater than 0
-		if result.Amount <= 0 {
+	// Check if 'now' is between 'StartDate' and 'EndDate'
+	if !now.Before(result.StartDate) && !now.After(result.EndDate) {
+		// If within the date range, check if the amount left is greater than 0
+		if result.Amount-spend <= 0 {
 
 			return false, nil
 		}
 	}
 
 	return true, nil
-}
\ No newline at end of file
+}
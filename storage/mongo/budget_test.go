@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"budget-service/appctx"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCheckBudget(t *testing.T) {
+	db := testDB(t)
+	budgets := &BudgetStorage{db}
+	ctx := appctx.WithUserID(context.Background(), "user-a")
+
+	left, err := budgets.CheckBudget(ctx, "user-a", 50)
+	if err != nil || !left {
+		t.Fatalf("without a budget: got %v, %v, want true and no error", left, err)
+	}
+
+	now := time.Now()
+	_, err = db.Collection("budgets").InsertOne(ctx, bson.M{
+		"user_id":    "user-a",
+		"amount":     float32(100),
+		"start_date": now.Add(-time.Hour),
+		"end_date":   now.Add(time.Hour),
+		"deleted_at": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		spend float32
+		want  bool
+	}{
+		{50, true},
+		{100, false},
+		{150, false},
+	}
+	for _, tt := range tests {
+		left, err := budgets.CheckBudget(ctx, "user-a", tt.spend)
+		if err != nil {
+			t.Fatalf("spend %.2f: %v", tt.spend, err)
+		}
+		if left != tt.want {
+			t.Errorf("spend %.2f: budget left %v, want %v", tt.spend, left, tt.want)
+		}
+	}
+}
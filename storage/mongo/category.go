@@ -2,9 +2,10 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"log"
+	"time"
 
+	"budget-service/apperr"
 	pb "budget-service/genproto"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,18 +21,20 @@ func NewCategoryStorage(db *mongo.Database) *CategoryStorage {
 	return &CategoryStorage{db: db}
 }
 
-func (s *CategoryStorage) CreateCategory(req *pb.CreateCategoryRequest) (*pb.MessageResponse, error) {
+func (s *CategoryStorage) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.MessageResponse, error) {
 	coll := s.db.Collection("categories")
 
 	// Generate a new ObjectID for the category
 	objID := primitive.NewObjectID()
 	req.Id = objID.Hex() // Set the ID field in the request
 
-	_, err := coll.InsertOne(context.Background(), bson.M{
+	_, err := coll.InsertOne(ctx, bson.M{
 		"_id":     objID, // Use ObjectID for _id
 		"user_id": req.UserId,
 		"name":    req.Name,
 		"type":    req.Type,
+		"icon":    req.Icon,
+		"version": 1,
 	})
 	if err != nil {
 		log.Printf("Failed to create category: %v", err)
@@ -41,7 +44,7 @@ func (s *CategoryStorage) CreateCategory(req *pb.CreateCategoryRequest) (*pb.Mes
 	return &pb.MessageResponse{Message: "Category created successfully"}, nil
 }
 
-func (s *CategoryStorage) ListCategories(req *pb.ListCategoriesRequest) (*pb.ListResponse, error) {
+func (s *CategoryStorage) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListResponse, error) {
 	coll := s.db.Collection("categories")
 
 	filter := bson.M{}
@@ -55,20 +58,23 @@ func (s *CategoryStorage) ListCategories(req *pb.ListCategoriesRequest) (*pb.Lis
 		filter["type"] = req.Type
 	}
 
-	cursor, err := coll.Find(context.Background(), filter)
+	cursor, err := coll.Find(ctx, notDeleted(owned(ctx, filter)))
 	if err != nil {
 		log.Printf("Failed to list categories: %v", err)
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(ctx)
 
 	var categories []*pb.CategoryResponse
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var categoryData struct {
-			ID     primitive.ObjectID `bson:"_id"`
-			UserId string             `bson:"user_id"`
-			Name   string             `bson:"name"`
-			Type   string             `bson:"type"`
+			ID         primitive.ObjectID `bson:"_id"`
+			UserId     string             `bson:"user_id"`
+			Name       string             `bson:"name"`
+			Type       string             `bson:"type"`
+			Icon       string             `bson:"icon"`
+			TemplateId string             `bson:"template_id"`
+			Version    int64              `bson:"version"`
 		}
 		if err := cursor.Decode(&categoryData); err != nil {
 			log.Printf("Failed to decode category: %v", err)
@@ -80,6 +86,9 @@ func (s *CategoryStorage) ListCategories(req *pb.ListCategoriesRequest) (*pb.Lis
 			UserId:     categoryData.UserId,
 			Name:       categoryData.Name,
 			Type:       categoryData.Type,
+			Icon:       categoryData.Icon,
+			TemplateId: categoryData.TemplateId,
+			Version:    categoryData.Version,
 		}
 		categories = append(categories, category)
 	}
@@ -92,22 +101,28 @@ func (s *CategoryStorage) ListCategories(req *pb.ListCategoriesRequest) (*pb.Lis
 	return &pb.ListResponse{Categories: categories}, nil
 }
 
-func (s *CategoryStorage) GetCategoryById(req *pb.GetCategoryByIdRequest) (*pb.CategoryResponse, error) {
+func (s *CategoryStorage) GetCategoryById(ctx context.Context, req *pb.GetCategoryByIdRequest) (*pb.CategoryResponse, error) {
 	coll := s.db.Collection("categories")
 
 	objID, err := primitive.ObjectIDFromHex(req.CategoryId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid category ID: %v", err)
+		return nil, apperr.InvalidArgument("category_id", "invalid category ID: %v", err)
 	}
 
 	var categoryData struct {
-		ID     primitive.ObjectID `bson:"_id"`
-		UserId string             `bson:"user_id"`
-		Name   string             `bson:"name"`
-		Type   string             `bson:"type"`
-	}
-	err = coll.FindOne(context.Background(), bson.M{"_id": objID}).Decode(&categoryData)
+		ID         primitive.ObjectID `bson:"_id"`
+		UserId     string             `bson:"user_id"`
+		Name       string             `bson:"name"`
+		Type       string             `bson:"type"`
+		Icon       string             `bson:"icon"`
+		TemplateId string             `bson:"template_id"`
+		Version    int64              `bson:"version"`
+	}
+	err = coll.FindOne(ctx, notDeleted(owned(ctx, bson.M{"_id": objID}))).Decode(&categoryData)
 	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("category", req.CategoryId)
+		}
 		log.Printf("Failed to get category by id: %v", err)
 		return nil, err
 	}
@@ -117,17 +132,20 @@ func (s *CategoryStorage) GetCategoryById(req *pb.GetCategoryByIdRequest) (*pb.C
 		UserId:     categoryData.UserId,
 		Name:       categoryData.Name,
 		Type:       categoryData.Type,
+		Icon:       categoryData.Icon,
+		TemplateId: categoryData.TemplateId,
+		Version:    categoryData.Version,
 	}
 
 	return category, nil
 }
 
-func (s *CategoryStorage) UpdateCategory(req *pb.UpdateCategoryRequest) (*pb.MessageResponse, error) {
+func (s *CategoryStorage) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.MessageResponse, error) {
 	coll := s.db.Collection("categories")
 
 	objID, err := primitive.ObjectIDFromHex(req.CategoryId)
 	if err != nil {
-		return &pb.MessageResponse{Message: "Invalid category ID"}, err
+		return &pb.MessageResponse{Message: "Invalid category ID"}, apperr.InvalidArgument("category_id", "invalid category ID: %v", err)
 	}
 
 	update := bson.M{}
@@ -137,12 +155,15 @@ func (s *CategoryStorage) UpdateCategory(req *pb.UpdateCategoryRequest) (*pb.Mes
 	if req.Type != "" {
 		update["type"] = req.Type
 	}
+	if req.Icon != "" {
+		update["icon"] = req.Icon
+	}
 
 	if len(update) == 0 {
 		return &pb.MessageResponse{Message: "Nothing to update"}, nil
 	}
 
-	_, err = coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{"$set": update})
+	err = updateVersioned(ctx, coll, objID, req.Version, update, "category")
 	if err != nil {
 		log.Printf("Failed to update category: %v", err)
 		return &pb.MessageResponse{Message: "Failed to update category"}, err
@@ -151,19 +172,32 @@ func (s *CategoryStorage) UpdateCategory(req *pb.UpdateCategoryRequest) (*pb.Mes
 	return &pb.MessageResponse{Message: "Category updated successfully"}, nil
 }
 
-func (s *CategoryStorage) DeleteCategory(req *pb.DeleteCategoryRequest) (*pb.CategoryDeleteResponse, error) {
+func (s *CategoryStorage) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.CategoryDeleteResponse, error) {
 	coll := s.db.Collection("categories")
 
 	objID, err := primitive.ObjectIDFromHex(req.CategoryId)
 	if err != nil {
-		return &pb.CategoryDeleteResponse{Success: false}, fmt.Errorf("invalid category ID: %v", err)
+		return &pb.CategoryDeleteResponse{Success: false}, apperr.InvalidArgument("category_id", "invalid category ID: %v", err)
 	}
 
-	_, err = coll.DeleteOne(context.Background(), bson.M{"_id": objID})
+	deleted, err := softDelete(ctx, coll, bson.M{"_id": objID}, time.Now())
 	if err != nil {
 		log.Printf("Failed to delete category: %v", err)
 		return &pb.CategoryDeleteResponse{Success: false}, err
 	}
+	if deleted == 0 {
+		return &pb.CategoryDeleteResponse{Success: false}, apperr.NotFound("category", req.CategoryId)
+	}
 
 	return &pb.CategoryDeleteResponse{Success: true}, nil
 }
+
+// RestoreCategory takes a deleted category out of the trash
+func (s *CategoryStorage) RestoreCategory(ctx context.Context, categoryID string) error {
+	_, err := restore(ctx, s.db.Collection("categories"), categoryID)
+	if err != nil {
+		log.Printf("Failed to restore category: %v", err)
+		return err
+	}
+	return nil
+}
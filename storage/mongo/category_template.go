@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	pb "budget-service/genproto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultLocale is used when a template has no name for the requested locale
+const defaultLocale = "en"
+
+// categoryTemplate is a system-level category shared by all users
+type categoryTemplate struct {
+	ID    string            `bson:"_id"`
+	Type  string            `bson:"type"`
+	Icon  string            `bson:"icon"`
+	Names map[string]string `bson:"names"`
+}
+
+// name returns the template name for the locale, falling back to English
+func (t categoryTemplate) name(locale string) string {
+	if name, ok := t.Names[locale]; ok && name != "" {
+		return name
+	}
+	return t.Names[defaultLocale]
+}
+
+// defaultCategoryTemplates are upserted into the category_templates collection on startup
+var defaultCategoryTemplates = []categoryTemplate{
+	{ID: "salary", Type: "income", Icon: "briefcase", Names: map[string]string{"en": "Salary", "ru": "Зарплата", "uz": "Maosh"}},
+	{ID: "business", Type: "income", Icon: "store", Names: map[string]string{"en": "Business", "ru": "Бизнес", "uz": "Biznes"}},
+	{ID: "gifts_received", Type: "income", Icon: "gift", Names: map[string]string{"en": "Gifts", "ru": "Подарки", "uz": "Sovg'alar"}},
+	{ID: "other_income", Type: "income", Icon: "plus-circle", Names: map[string]string{"en": "Other income", "ru": "Прочие доходы", "uz": "Boshqa daromadlar"}},
+	{ID: "groceries", Type: "expense", Icon: "cart", Names: map[string]string{"en": "Groceries", "ru": "Продукты", "uz": "Oziq-ovqat"}},
+	{ID: "restaurants", Type: "expense", Icon: "utensils", Names: map[string]string{"en": "Restaurants", "ru": "Рестораны", "uz": "Restoranlar"}},
+	{ID: "transport", Type: "expense", Icon: "bus", Names: map[string]string{"en": "Transport", "ru": "Транспорт", "uz": "Transport"}},
+	{ID: "housing", Type: "expense", Icon: "home", Names: map[string]string{"en": "Housing", "ru": "Жильё", "uz": "Uy-joy"}},
+	{ID: "utilities", Type: "expense", Icon: "bolt", Names: map[string]string{"en": "Utilities", "ru": "Коммунальные услуги", "uz": "Kommunal xizmatlar"}},
+	{ID: "health", Type: "expense", Icon: "heart", Names: map[string]string{"en": "Health", "ru": "Здоровье", "uz": "Sog'liq"}},
+	{ID: "education", Type: "expense", Icon: "book", Names: map[string]string{"en": "Education", "ru": "Образование", "uz": "Ta'lim"}},
+	{ID: "entertainment", Type: "expense", Icon: "film", Names: map[string]string{"en": "Entertainment", "ru": "Развлечения", "uz": "Ko'ngilochar"}},
+	{ID: "shopping", Type: "expense", Icon: "bag", Names: map[string]string{"en": "Shopping", "ru": "Покупки", "uz": "Xaridlar"}},
+	{ID: "other_expense", Type: "expense", Icon: "minus-circle", Names: map[string]string{"en": "Other expenses", "ru": "Прочие расходы", "uz": "Boshqa xarajatlar"}},
+}
+
+// EnsureCategoryTemplates upserts the default templates so that new releases can add or rename them
+func EnsureCategoryTemplates(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection("category_templates")
+
+	for _, t := range defaultCategoryTemplates {
+		_, err := coll.ReplaceOne(ctx, bson.M{"_id": t.ID}, t, options.Replace().SetUpsert(true))
+		if err != nil {
+			log.Printf("Failed to upsert category template %s: %v", t.ID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CategoryStorage) listTemplates(ctx context.Context, categoryType string) ([]categoryTemplate, error) {
+	coll := s.db.Collection("category_templates")
+
+	filter := bson.M{}
+	if categoryType != "" {
+		filter["type"] = categoryType
+	}
+
+	cursor, err := coll.Find(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to list category templates: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var templates []categoryTemplate
+	if err := cursor.All(ctx, &templates); err != nil {
+		log.Printf("Failed to decode category templates: %v", err)
+		return nil, err
+	}
+	return templates, nil
+}
+
+// ListCategoryTemplates returns the shared templates with names resolved for the requested locale
+func (s *CategoryStorage) ListCategoryTemplates(ctx context.Context, req *pb.ListCategoryTemplatesRequest) (*pb.ListCategoryTemplatesResponse, error) {
+	templates, err := s.listTemplates(ctx, req.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*pb.CategoryTemplate
+	for _, t := range templates {
+		resp = append(resp, &pb.CategoryTemplate{
+			TemplateId: t.ID,
+			Type:       t.Type,
+			Icon:       t.Icon,
+			Name:       t.name(req.Locale),
+			Names:      t.Names,
+		})
+	}
+
+	return &pb.ListCategoryTemplatesResponse{Templates: resp}, nil
+}
+
+// SeedDefaultCategories copies every template the user doesn't have yet into their own categories.
+// The copies are regular user categories, so renaming or deleting them never touches the templates.
+func (s *CategoryStorage) SeedDefaultCategories(ctx context.Context, req *pb.SeedDefaultCategoriesRequest) (*pb.SeedDefaultCategoriesResponse, error) {
+	coll := s.db.Collection("categories")
+
+	templates, err := s.listTemplates(ctx, "")
+	if err != nil {
+		return &pb.SeedDefaultCategoriesResponse{Message: "Failed to seed categories"}, err
+	}
+
+	// Categories in the trash count as seeded so deleting a default doesn't bring it back
+	existing, err := coll.Distinct(ctx, "template_id", owned(ctx, bson.M{"user_id": req.UserId}))
+	if err != nil {
+		log.Printf("Failed to list seeded categories: %v", err)
+		return &pb.SeedDefaultCategoriesResponse{Message: "Failed to seed categories"}, err
+	}
+	seeded := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		if templateId, ok := id.(string); ok {
+			seeded[templateId] = true
+		}
+	}
+
+	var docs []interface{}
+	for _, t := range templates {
+		if seeded[t.ID] {
+			continue
+		}
+		docs = append(docs, bson.M{
+			"_id":         primitive.NewObjectID(),
+			"user_id":     req.UserId,
+			"name":        t.name(req.Locale),
+			"type":        t.Type,
+			"icon":        t.Icon,
+			"template_id": t.ID,
+			"version":     1,
+		})
+	}
+
+	if len(docs) == 0 {
+		return &pb.SeedDefaultCategoriesResponse{Message: "Default categories already seeded"}, nil
+	}
+
+	if _, err := coll.InsertMany(ctx, docs); err != nil {
+		log.Printf("Failed to seed categories: %v", err)
+		return &pb.SeedDefaultCategoriesResponse{Message: "Failed to seed categories"}, err
+	}
+
+	return &pb.SeedDefaultCategoriesResponse{
+		Seeded:  int32(len(docs)),
+		Message: "Default categories seeded successfully",
+	}, nil
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"budget-service/dates"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stringDates are the fields that used to be stored as YYYY-MM-DD strings.
+// End dates were inclusive, so they become the last millisecond of their day.
+var stringDates = []struct {
+	collection string
+	field      string
+	endOfDay   bool
+}{
+	{"budgets", "start_date", false},
+	{"budgets", "end_date", true},
+	{"goals", "deadline", false},
+	{"transactions", "date", false},
+}
+
+// zonedCollections keep the time zone their dates are evaluated in
+var zonedCollections = []string{"budgets", "goals", "transactions"}
+
+// timeZoneOrUTC returns zone, or UTC when the caller did not give one
+func timeZoneOrUTC(zone string) string {
+	if zone == "" {
+		return "UTC"
+	}
+	return zone
+}
+
+// asTime converts a request timestamp to the BSON date stored in MongoDB
+func asTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// timestamp converts a stored BSON date to its proto form, leaving unset dates nil
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// MigrateStringDates converts dates stored as strings into BSON dates, reading each
+// string as a UTC day, and gives documents without a time zone UTC. Strings that
+// aren't dates are moved to <field>_legacy so the document still decodes.
+// Documents already migrated are left alone, so it is safe to run on every start.
+func MigrateStringDates(ctx context.Context, db *mongo.Database) error {
+	for _, d := range stringDates {
+		coll := db.Collection(d.collection)
+		opts := options.Find().SetProjection(bson.M{d.field: 1})
+		cursor, err := coll.Find(ctx, bson.M{d.field: bson.M{"$type": "string"}}, opts)
+		if err != nil {
+			log.Printf("Failed to migrate %s.%s: %v", d.collection, d.field, err)
+			return err
+		}
+
+		var migrated, invalid int
+		for cursor.Next(ctx) {
+			id := cursor.Current.Lookup("_id")
+			value := cursor.Current.Lookup(d.field).StringValue()
+
+			update := bson.M{}
+			day, err := time.Parse(dates.Layout, value)
+			if err != nil {
+				update["$set"] = bson.M{d.field + "_legacy": value}
+				update["$unset"] = bson.M{d.field: ""}
+				invalid++
+			} else {
+				if d.endOfDay {
+					day = day.Add(24*time.Hour - time.Millisecond)
+				}
+				update["$set"] = bson.M{d.field: day}
+				migrated++
+			}
+			if _, err := coll.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+				cursor.Close(ctx)
+				log.Printf("Failed to migrate %s.%s: %v", d.collection, d.field, err)
+				return err
+			}
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			log.Printf("Failed to migrate %s.%s: %v", d.collection, d.field, err)
+			return err
+		}
+		if migrated > 0 || invalid > 0 {
+			log.Printf("Migrated %d %s.%s strings to dates, %d were not dates", migrated, d.collection, d.field, invalid)
+		}
+	}
+
+	for _, name := range zonedCollections {
+		filter := bson.M{"time_zone": bson.M{"$exists": false}}
+		if _, err := db.Collection(name).UpdateMany(ctx, filter, bson.M{"$set": bson.M{"time_zone": "UTC"}}); err != nil {
+			log.Printf("Failed to backfill time zones of %s: %v", name, err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	"budget-service/validation"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// pivotCurrency is used to build cross rates when no direct or inverse rate is stored
+const pivotCurrency = "USD"
+
+// ExchangeRateStorage keeps dated exchange rates in MongoDB.
+// A rate means 1 unit of base_currency costs rate units of quote_currency.
+type ExchangeRateStorage struct {
+	db *mongo.Database
+}
+
+// NewExchangeRateStorage initializes a new ExchangeRateStorage
+func NewExchangeRateStorage(db *mongo.Database) *ExchangeRateStorage {
+	return &ExchangeRateStorage{db: db}
+}
+
+type exchangeRate struct {
+	BaseCurrency  string  `bson:"base_currency" json:"base_currency"`
+	QuoteCurrency string  `bson:"quote_currency" json:"quote_currency"`
+	Rate          float64 `bson:"rate" json:"rate"`
+	Date          string  `bson:"date" json:"date"`
+}
+
+func (s *ExchangeRateStorage) upsert(ctx context.Context, rate exchangeRate) error {
+	coll := s.db.Collection("exchange_rates")
+
+	filter := bson.M{
+		"base_currency":  rate.BaseCurrency,
+		"quote_currency": rate.QuoteCurrency,
+		"date":           rate.Date,
+	}
+	_, err := coll.ReplaceOne(ctx, filter, rate, options.Replace().SetUpsert(true))
+	return err
+}
+
+// SetExchangeRate stores the rate for a currency pair on a date, replacing any previous value
+func (s *ExchangeRateStorage) SetExchangeRate(ctx context.Context, req *pb.ExchangeRate) (*pb.ExchangeRateResponse, error) {
+	if req.Rate <= 0 {
+		return &pb.ExchangeRateResponse{Message: "Invalid exchange rate"}, apperr.InvalidArgument("rate", "exchange rate must be positive")
+	}
+
+	err := s.upsert(ctx, exchangeRate{
+		BaseCurrency:  req.BaseCurrency,
+		QuoteCurrency: req.QuoteCurrency,
+		Rate:          req.Rate,
+		Date:          req.Date,
+	})
+	if err != nil {
+		log.Printf("Failed to set exchange rate: %v", err)
+		return &pb.ExchangeRateResponse{Message: "Failed to set exchange rate"}, err
+	}
+
+	return &pb.ExchangeRateResponse{Message: "Exchange rate saved successfully"}, nil
+}
+
+// ListExchangeRates lists stored rates, optionally filtered by pair and date
+func (s *ExchangeRateStorage) ListExchangeRates(ctx context.Context, req *pb.ListExchangeRatesRequest) (*pb.ListExchangeRatesResponse, error) {
+	coll := s.db.Collection("exchange_rates")
+
+	filter := bson.M{}
+	if req.BaseCurrency != "" {
+		filter["base_currency"] = req.BaseCurrency
+	}
+	if req.QuoteCurrency != "" {
+		filter["quote_currency"] = req.QuoteCurrency
+	}
+	if req.Date != "" {
+		filter["date"] = req.Date
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}})
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Failed to list exchange rates: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rates []*pb.ExchangeRate
+	for cursor.Next(ctx) {
+		var rate exchangeRate
+		if err := cursor.Decode(&rate); err != nil {
+			log.Printf("Failed to decode exchange rate: %v", err)
+			return nil, err
+		}
+		rates = append(rates, &pb.ExchangeRate{
+			BaseCurrency:  rate.BaseCurrency,
+			QuoteCurrency: rate.QuoteCurrency,
+			Rate:          rate.Rate,
+			Date:          rate.Date,
+		})
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+
+	return &pb.ListExchangeRatesResponse{Rates: rates}, nil
+}
+
+// latestRate finds the most recent stored rate for the pair on or before date
+func (s *ExchangeRateStorage) latestRate(ctx context.Context, from, to, date string) (*exchangeRate, error) {
+	coll := s.db.Collection("exchange_rates")
+
+	filter := bson.M{"base_currency": from, "quote_currency": to}
+	if date != "" {
+		filter["date"] = bson.M{"$lte": date}
+	}
+
+	var rate exchangeRate
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+	err := coll.FindOne(ctx, filter, opts).Decode(&rate)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		log.Printf("Failed to get exchange rate: %v", err)
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// directRate looks up the pair as stored or as its inverse, whichever was set
+// most recently. On the same date the pair as stored wins.
+func (s *ExchangeRateStorage) directRate(ctx context.Context, from, to, date string) (float64, bool, error) {
+	if from == to {
+		return 1, true, nil
+	}
+	direct, err := s.latestRate(ctx, from, to, date)
+	if err != nil {
+		return 0, false, err
+	}
+	inverse, err := s.latestRate(ctx, to, from, date)
+	if err != nil {
+		return 0, false, err
+	}
+	if inverse != nil && (direct == nil || inverse.Date > direct.Date) {
+		return 1 / inverse.Rate, true, nil
+	}
+	if direct != nil {
+		return direct.Rate, true, nil
+	}
+	return 0, false, nil
+}
+
+// GetRate returns how many units of `to` one unit of `from` was worth on date.
+// Rates are taken from the latest entry on or before the date, trying the direct
+// pair, its inverse and finally a cross rate through the pivot currency.
+func (s *ExchangeRateStorage) GetRate(ctx context.Context, from, to, date string) (float64, error) {
+	rate, ok, err := s.directRate(ctx, from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return rate, nil
+	}
+
+	toPivot, ok, err := s.directRate(ctx, from, pivotCurrency, date)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		fromPivot, ok, err := s.directRate(ctx, pivotCurrency, to, date)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return toPivot * fromPivot, nil
+		}
+	}
+
+	return 0, apperr.NotFound("exchange rate", fmt.Sprintf("%s/%s on %s", from, to, date))
+}
+
+// LoadExchangeRates upserts the rates from a JSON file holding an array of
+// {"base_currency", "quote_currency", "rate", "date"} objects
+func (s *ExchangeRateStorage) LoadExchangeRates(ctx context.Context, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("read exchange rates file: %v", err)
+	}
+
+	var rates []exchangeRate
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return 0, fmt.Errorf("parse exchange rates file: %v", err)
+	}
+
+	// The file is checked with the rules SetExchangeRate requests go through, and
+	// nothing is loaded unless every row passes
+	for i, rate := range rates {
+		err := validation.Validate(&pb.ExchangeRate{
+			BaseCurrency:  rate.BaseCurrency,
+			QuoteCurrency: rate.QuoteCurrency,
+			Rate:          rate.Rate,
+			Date:          rate.Date,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("exchange rate %d in %s: %v", i+1, path, err)
+		}
+	}
+	for _, rate := range rates {
+		if err := s.upsert(ctx, rate); err != nil {
+			log.Printf("Failed to load exchange rate: %v", err)
+			return 0, err
+		}
+	}
+	return len(rates), nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Bad rows are rejected before anything is written, so no server is needed
+func TestLoadExchangeRatesRejectsBadRows(t *testing.T) {
+	rates := NewExchangeRateStorage(offlineDB(t))
+	tests := []struct {
+		name  string
+		row   string
+		field string
+	}{
+		{"zero rate", `{"base_currency": "USD", "quote_currency": "UZS", "rate": 0, "date": "2024-05-01"}`, "rate"},
+		{"negative rate", `{"base_currency": "USD", "quote_currency": "UZS", "rate": -1, "date": "2024-05-01"}`, "rate"},
+		{"lower case currency", `{"base_currency": "usd", "quote_currency": "UZS", "rate": 12650, "date": "2024-05-01"}`, "base_currency"},
+		{"missing currency", `{"base_currency": "USD", "rate": 12650, "date": "2024-05-01"}`, "quote_currency"},
+		{"same currency", `{"base_currency": "USD", "quote_currency": "USD", "rate": 1, "date": "2024-05-01"}`, "quote_currency"},
+		{"bad date", `{"base_currency": "USD", "quote_currency": "UZS", "rate": 12650, "date": "01.05.2024"}`, "date"},
+		{"missing date", `{"base_currency": "USD", "quote_currency": "UZS", "rate": 12650}`, "date"},
+	}
+	good := `{"base_currency": "EUR", "quote_currency": "USD", "rate": 1.08, "date": "2024-05-01"}`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rates.json")
+			if err := os.WriteFile(path, []byte("["+good+", "+tt.row+"]"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			n, err := rates.LoadExchangeRates(context.Background(), path)
+			if err == nil {
+				t.Fatalf("loaded %d rates, want an error", n)
+			}
+			if !strings.Contains(err.Error(), "exchange rate 2") || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("error %q doesn't name row 2 and %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestDirectRatePrefersTheLatest(t *testing.T) {
+	rates := NewExchangeRateStorage(testDB(t))
+	ctx := context.Background()
+	for _, rate := range []exchangeRate{
+		{BaseCurrency: "USD", QuoteCurrency: "UZS", Rate: 12000, Date: "2024-01-01"},
+		{BaseCurrency: "UZS", QuoteCurrency: "USD", Rate: 1.0 / 12500, Date: "2024-03-01"},
+		{BaseCurrency: "USD", QuoteCurrency: "UZS", Rate: 12800, Date: "2024-05-01"},
+		{BaseCurrency: "UZS", QuoteCurrency: "USD", Rate: 1.0 / 13000, Date: "2024-05-01"},
+	} {
+		if err := rates.upsert(ctx, rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		date     string
+		want     float64
+	}{
+		{"only the direct rate", "USD", "UZS", "2024-02-01", 12000},
+		{"fresher inverse rate", "USD", "UZS", "2024-04-01", 12500},
+		{"fresher direct rate", "UZS", "USD", "2024-02-01", 1.0 / 12000},
+		{"same date keeps the pair as asked", "USD", "UZS", "2024-06-01", 12800},
+		{"same date from the other side", "UZS", "USD", "2024-06-01", 1.0 / 13000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.GetRate(ctx, tt.from, tt.to, tt.date)
+			if err != nil {
+				t.Fatalf("GetRate: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9*tt.want {
+				t.Errorf("GetRate(%s, %s, %s) = %v, want %v", tt.from, tt.to, tt.date, got, tt.want)
+			}
+		})
+	}
+}
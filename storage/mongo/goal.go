@@ -2,10 +2,11 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
+	"budget-service/apperr"
+	"budget-service/dates"
 	pb "budget-service/genproto"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -24,21 +25,23 @@ func NewGoalStorage(db *mongo.Database) *GoalStorage {
 }
 
 // CreateGoal creates a new goal in the database
-func (s *GoalStorage) CreateGoal(req *pb.CreateGoalRequest) (*pb.Responsee, error) {
+func (s *GoalStorage) CreateGoal(ctx context.Context, req *pb.CreateGoalRequest) (*pb.Responsee, error) {
 	coll := s.db.Collection("goals")
 
 	// Generate a new ObjectID for the goal
 	objID := primitive.NewObjectID()
 	req.Id = objID.Hex() // Set the ID field in the request
 
-	_, err := coll.InsertOne(context.Background(), bson.M{
+	_, err := coll.InsertOne(ctx, bson.M{
 		"_id":            objID, // Use ObjectID for _id
 		"user_id":        req.UserId,
 		"name":           req.Name,
 		"target_amount":  req.TargetAmount,
 		"current_amount": req.CurrentAmount,
-		"deadline":       req.Deadline,
+		"deadline":       asTime(req.Deadline),
+		"time_zone":      timeZoneOrUTC(req.TimeZone),
 		"status":         req.Status,
+		"version":        1,
 	})
 	if err != nil {
 		log.Printf("Failed to create goal: %v", err)
@@ -49,7 +52,7 @@ func (s *GoalStorage) CreateGoal(req *pb.CreateGoalRequest) (*pb.Responsee, erro
 }
 
 // ListGoals lists all goals, optionally filtering by various criteria
-func (s *GoalStorage) ListGoals(req *pb.ListGoalsRequest) (*pb.ListGoalsResponse, error) {
+func (s *GoalStorage) ListGoals(ctx context.Context, req *pb.ListGoalsRequest) (*pb.ListGoalsResponse, error) {
 	coll := s.db.Collection("goals")
 
 	filter := bson.M{}
@@ -65,30 +68,33 @@ func (s *GoalStorage) ListGoals(req *pb.ListGoalsRequest) (*pb.ListGoalsResponse
 	if req.CurrentAmount > 0 {
 		filter["current_amount"] = req.CurrentAmount
 	}
-	if req.Deadline != "" {
-		filter["deadline"] = req.Deadline
+	// Goals due by the given time
+	if req.Deadline != nil {
+		filter["deadline"] = bson.M{"$lte": req.Deadline.AsTime()}
 	}
 	if req.Status != "" {
 		filter["status"] = req.Status
 	}
 
-	cursor, err := coll.Find(context.Background(), filter)
+	cursor, err := coll.Find(ctx, notDeleted(owned(ctx, filter)))
 	if err != nil {
 		log.Printf("Failed to list goals: %v", err)
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(ctx)
 
 	var goals []*pb.GoalResponse
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var goalData struct {
 			ID            primitive.ObjectID `bson:"_id"` // BSON tag for ID field
 			UserId        string             `bson:"user_id"`
 			Name          string             `bson:"name"`
 			TargetAmount  float32            `bson:"target_amount"`
 			CurrentAmount float32            `bson:"current_amount"`
-			Deadline      string             `bson:"deadline"`
+			Deadline      time.Time          `bson:"deadline"`
+			TimeZone      string             `bson:"time_zone"`
 			Status        string             `bson:"status"`
+			Version       int64              `bson:"version"`
 		}
 		if err := cursor.Decode(&goalData); err != nil {
 			log.Printf("Failed to decode goal: %v", err)
@@ -97,11 +103,14 @@ func (s *GoalStorage) ListGoals(req *pb.ListGoalsRequest) (*pb.ListGoalsResponse
 
 		goal := &pb.GoalResponse{
 			GoalId:        goalData.ID.Hex(),
+			UserId:        goalData.UserId,
 			Name:          goalData.Name,
 			TargetAmount:  goalData.TargetAmount,
 			CurrentAmount: goalData.CurrentAmount,
-			Deadline:      goalData.Deadline,
+			Deadline:      timestamp(goalData.Deadline),
+			TimeZone:      goalData.TimeZone,
 			Status:        goalData.Status,
+			Version:       goalData.Version,
 		}
 		goals = append(goals, goal)
 	}
@@ -115,12 +124,12 @@ func (s *GoalStorage) ListGoals(req *pb.ListGoalsRequest) (*pb.ListGoalsResponse
 }
 
 // GetGoalById retrieves a goal by its ID
-func (s *GoalStorage) GetGoalById(req *pb.GetGoalByIdRequest) (*pb.GoalResponse, error) {
+func (s *GoalStorage) GetGoalById(ctx context.Context, req *pb.GetGoalByIdRequest) (*pb.GoalResponse, error) {
 	coll := s.db.Collection("goals")
 
 	objID, err := primitive.ObjectIDFromHex(req.GoalId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid goal ID: %v", err)
+		return nil, apperr.InvalidArgument("goal_id", "invalid goal ID: %v", err)
 	}
 
 	var goalData struct {
@@ -129,14 +138,16 @@ func (s *GoalStorage) GetGoalById(req *pb.GetGoalByIdRequest) (*pb.GoalResponse,
 		Name          string             `bson:"name"`
 		TargetAmount  float32            `bson:"target_amount"`
 		CurrentAmount float32            `bson:"current_amount"`
-		Deadline      string             `bson:"deadline"`
+		Deadline      time.Time          `bson:"deadline"`
+		TimeZone      string             `bson:"time_zone"`
 		Status        string             `bson:"status"`
+		Version       int64              `bson:"version"`
 	}
 
-	err = coll.FindOne(context.Background(), bson.M{"_id": objID}).Decode(&goalData)
+	err = coll.FindOne(ctx, notDeleted(owned(ctx, bson.M{"_id": objID}))).Decode(&goalData)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("goal not found")
+			return nil, apperr.NotFound("goal", req.GoalId)
 		}
 		log.Printf("Failed to get goal by ID: %v", err)
 		return nil, err
@@ -144,23 +155,26 @@ func (s *GoalStorage) GetGoalById(req *pb.GetGoalByIdRequest) (*pb.GoalResponse,
 
 	goal := &pb.GoalResponse{
 		GoalId:        goalData.ID.Hex(),
+		UserId:        goalData.UserId,
 		Name:          goalData.Name,
 		TargetAmount:  goalData.TargetAmount,
 		CurrentAmount: goalData.CurrentAmount,
-		Deadline:      goalData.Deadline,
+		Deadline:      timestamp(goalData.Deadline),
+		TimeZone:      goalData.TimeZone,
 		Status:        goalData.Status,
+		Version:       goalData.Version,
 	}
 
 	return goal, nil
 }
 
 // UpdateGoal updates a goal based on the provided request data
-func (s *GoalStorage) UpdateGoal(req *pb.UpdateGoalRequest) (*pb.Responsee, error) {
+func (s *GoalStorage) UpdateGoal(ctx context.Context, req *pb.UpdateGoalRequest) (*pb.Responsee, error) {
 	coll := s.db.Collection("goals")
 
 	objID, err := primitive.ObjectIDFromHex(req.GoalId)
 	if err != nil {
-		return &pb.Responsee{Message: "Invalid goal ID"}, err
+		return &pb.Responsee{Message: "Invalid goal ID"}, apperr.InvalidArgument("goal_id", "invalid goal ID: %v", err)
 	}
 
 	update := bson.M{}
@@ -173,8 +187,11 @@ func (s *GoalStorage) UpdateGoal(req *pb.UpdateGoalRequest) (*pb.Responsee, erro
 	if req.CurrentAmount > 0 {
 		update["current_amount"] = req.CurrentAmount
 	}
-	if req.Deadline != "" {
-		update["deadline"] = req.Deadline
+	if req.Deadline != nil {
+		update["deadline"] = req.Deadline.AsTime()
+	}
+	if req.TimeZone != "" {
+		update["time_zone"] = req.TimeZone
 	}
 	if req.Status != "" {
 		update["status"] = req.Status
@@ -184,7 +201,7 @@ func (s *GoalStorage) UpdateGoal(req *pb.UpdateGoalRequest) (*pb.Responsee, erro
 		return &pb.Responsee{Message: "Nothing to update"}, nil
 	}
 
-	_, err = coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{"$set": update})
+	err = updateVersioned(ctx, coll, objID, req.Version, update, "goal")
 	if err != nil {
 		log.Printf("Failed to update goal: %v", err)
 		return &pb.Responsee{Message: "Failed to update goal"}, err
@@ -193,55 +210,70 @@ func (s *GoalStorage) UpdateGoal(req *pb.UpdateGoalRequest) (*pb.Responsee, erro
 	return &pb.Responsee{Message: "Goal updated successfully"}, nil
 }
 
-// DeleteGoal deletes a goal by its ID
-func (s *GoalStorage) DeleteGoal(req *pb.DeleteGoalRequest) (*pb.GoalDeleteResponse, error) {
+// DeleteGoal moves a goal to the trash
+func (s *GoalStorage) DeleteGoal(ctx context.Context, req *pb.DeleteGoalRequest) (*pb.GoalDeleteResponse, error) {
 	coll := s.db.Collection("goals")
 
 	objID, err := primitive.ObjectIDFromHex(req.GoalId)
 	if err != nil {
-		return &pb.GoalDeleteResponse{Success: false}, fmt.Errorf("invalid goal ID: %v", err)
+		return &pb.GoalDeleteResponse{Success: false}, apperr.InvalidArgument("goal_id", "invalid goal ID: %v", err)
 	}
 
-	_, err = coll.DeleteOne(context.Background(), bson.M{"_id": objID})
+	deleted, err := softDelete(ctx, coll, bson.M{"_id": objID}, time.Now())
 	if err != nil {
 		log.Printf("Failed to delete goal: %v", err)
 		return &pb.GoalDeleteResponse{Success: false}, err
 	}
+	if deleted == 0 {
+		return &pb.GoalDeleteResponse{Success: false}, apperr.NotFound("goal", req.GoalId)
+	}
 
 	return &pb.GoalDeleteResponse{Success: true}, nil
 }
 
+// RestoreGoal takes a deleted goal out of the trash
+func (s *GoalStorage) RestoreGoal(ctx context.Context, goalID string) error {
+	_, err := restore(ctx, s.db.Collection("goals"), goalID)
+	if err != nil {
+		log.Printf("Failed to restore goal: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (s *GoalStorage) CheckGoal(ctx context.Context, userId string) (bool, string, error) {
 	coll := s.db.Collection("goals")
 
 	// Define a struct to match the document structure
 	var result struct {
-		TargetAmount  float32 `bson:"target_amount"`
-		CurrentAmount float32 `bson:"current_amount"`
-		Deadline      string  `bson:"deadline"`
+		TargetAmount  float32   `bson:"target_amount"`
+		CurrentAmount float32   `bson:"current_amount"`
+		Deadline      time.Time `bson:"deadline"`
+		TimeZone      string    `bson:"time_zone"`
 	}
 
 	// Find the document for the given UserId
-	err := coll.FindOne(context.Background(), bson.M{"UserId": userId}).Decode(&result)
+	err := coll.FindOne(ctx, notDeleted(owned(ctx, bson.M{"user_id": userId}))).Decode(&result)
 	if err != nil {
 		log.Printf("Failed to get goal by UserId: %v", err)
 		return false, "", err
 	}
 
-	// Get the current date in the same string format as stored in MongoDB
-	now := time.Now().Format("2006-01-02")
+	// Compare calendar days in the goal's own time zone
+	loc := dates.Location(result.TimeZone)
+	now := time.Now().In(loc).Format(dates.Layout)
 
 	// Check if 'now' matches the 'Deadline'
-	if now == result.Deadline {
+	if now == result.Deadline.In(loc).Format(dates.Layout) {
 		if result.CurrentAmount < result.TargetAmount {
-			err = s.UpdateStatusByUserId(context.Background(), userId, "Filed")
+			err = s.UpdateStatusByUserId(ctx, userId, "Filed")
 			if err != nil {
 				log.Print("Error while update goal status")
 				return false, "", err
 			}
 			return false, "The deadline has passed, and you did not reach your savings goal.", nil
 		}
-		err = s.UpdateStatusByUserId(context.Background(), userId, "Success")
+		err = s.UpdateStatusByUserId(ctx, userId, "Success")
 		if err != nil {
 			log.Print("Error while update goal status")
 			return false, "", err
@@ -258,8 +290,9 @@ func (s *GoalStorage) UpdateStatusByUserId(ctx context.Context, userid string, s
 		"$set": bson.M{
 			"status": status,
 		},
+		"$inc": bumpVersion,
 	}
-	_, err := coll.UpdateOne(context.Background(), bson.M{"UserId": userid}, update)
+	_, err := coll.UpdateOne(ctx, notDeleted(owned(ctx, bson.M{"user_id": userid})), update)
 	if err != nil {
 		log.Printf("Failed to update goal status: %v", err)
 		return err
@@ -273,9 +306,10 @@ func (s *GoalStorage) UpdateGoalAmount(ctx context.Context, userId string, amoun
 	update := bson.M{
 		"$inc": bson.M{
 			"current_amount": +amount,
+			"version":        1,
 		},
 	}
-	_, err := coll.UpdateOne(context.Background(), bson.M{"user_id": userId}, update)
+	_, err := coll.UpdateOne(ctx, notDeleted(owned(ctx, bson.M{"user_id": userId})), update)
 	if err != nil {
 		log.Printf("Failed to update goal amount: %v", err)
 		return err
@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	"budget-service/appctx"
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	u "budget-service/storage"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// HouseholdStorage keeps households and their members in MongoDB. Accounts,
+// budgets and transactions are shared with a household through their household_id.
+type HouseholdStorage struct {
+	db *mongo.Database
+}
+
+// NewHouseholdStorage initializes a new HouseholdStorage
+func NewHouseholdStorage(db *mongo.Database) *HouseholdStorage {
+	return &HouseholdStorage{db: db}
+}
+
+type householdMember struct {
+	UserID string `bson:"user_id"`
+	Role   string `bson:"role"`
+}
+
+type householdDocument struct {
+	ID      primitive.ObjectID `bson:"_id"`
+	Name    string             `bson:"name"`
+	Members []householdMember  `bson:"members"`
+	Version int64              `bson:"version"`
+}
+
+// EnsureHouseholdIndex indexes members by user, since every query on shared
+// documents looks up the households of the user making it
+func EnsureHouseholdIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("households").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "members.user_id", Value: 1}},
+	})
+	return err
+}
+
+func (h householdDocument) toProto() *pb.Household {
+	household := &pb.Household{HouseholdId: h.ID.Hex(), Name: h.Name, Version: h.Version}
+	for _, m := range h.Members {
+		household.Members = append(household.Members, &pb.HouseholdMember{UserId: m.UserID, Role: m.Role})
+	}
+	return household
+}
+
+// member restricts filter to the households the request's user belongs to, or with
+// owner set, those they own
+func member(ctx context.Context, filter bson.M, owner bool) bson.M {
+	userID := appctx.UserID(ctx)
+	if userID == "" {
+		return filter
+	}
+	m := bson.M{"user_id": userID}
+	if owner {
+		m["role"] = "owner"
+	}
+	filter["members"] = bson.M{"$elemMatch": m}
+	return filter
+}
+
+// CreateHousehold stores a new household with ownerID as its only member
+func (s *HouseholdStorage) CreateHousehold(ctx context.Context, name, ownerID string) (*pb.Household, error) {
+	doc := householdDocument{
+		ID:      primitive.NewObjectID(),
+		Name:    name,
+		Members: []householdMember{{UserID: ownerID, Role: "owner"}},
+		Version: 1,
+	}
+	if _, err := s.db.Collection("households").InsertOne(ctx, doc); err != nil {
+		log.Printf("Failed to create household: %v", err)
+		return nil, err
+	}
+	return doc.toProto(), nil
+}
+
+// GetHouseholdById returns a household the user belongs to
+func (s *HouseholdStorage) GetHouseholdById(ctx context.Context, householdID string) (*pb.Household, error) {
+	objID, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return nil, apperr.InvalidArgument("household_id", "invalid household ID: %v", err)
+	}
+
+	var doc householdDocument
+	err = s.db.Collection("households").FindOne(ctx, member(ctx, bson.M{"_id": objID}, false)).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("household", householdID)
+		}
+		log.Printf("Failed to get household: %v", err)
+		return nil, err
+	}
+	return doc.toProto(), nil
+}
+
+// ListHouseholds lists the households userID belongs to
+func (s *HouseholdStorage) ListHouseholds(ctx context.Context, userID string) (*pb.ListHouseholdsResponse, error) {
+	filter := bson.M{}
+	if userID != "" {
+		filter["members.user_id"] = userID
+	}
+
+	cursor, err := s.db.Collection("households").Find(ctx, member(ctx, filter, false))
+	if err != nil {
+		log.Printf("Failed to list households: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	resp := &pb.ListHouseholdsResponse{}
+	for cursor.Next(ctx) {
+		var doc householdDocument
+		if err := cursor.Decode(&doc); err != nil {
+			log.Printf("Failed to decode household: %v", err)
+			return nil, err
+		}
+		resp.Households = append(resp.Households, doc.toProto())
+	}
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateHousehold replaces the name and members of a household the user owns,
+// provided it is still at the version the client read
+func (s *HouseholdStorage) UpdateHousehold(ctx context.Context, household *pb.Household) error {
+	coll := s.db.Collection("households")
+
+	objID, err := primitive.ObjectIDFromHex(household.HouseholdId)
+	if err != nil {
+		return apperr.InvalidArgument("household_id", "invalid household ID: %v", err)
+	}
+	if household.Version <= 0 {
+		return apperr.InvalidArgument("version", "household version is required")
+	}
+
+	members := make([]householdMember, 0, len(household.Members))
+	for _, m := range household.Members {
+		members = append(members, householdMember{UserID: m.UserId, Role: m.Role})
+	}
+
+	filter := member(ctx, bson.M{"_id": objID, "version": household.Version}, true)
+	update := bson.M{"$set": bson.M{"name": household.Name, "members": members}, "$inc": bumpVersion}
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Failed to update household: %v", err)
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := coll.CountDocuments(ctx, member(ctx, bson.M{"_id": objID}, true))
+	if err != nil {
+		log.Printf("Failed to update household: %v", err)
+		return err
+	}
+	if count == 0 {
+		return apperr.NotFound("household", household.HouseholdId)
+	}
+	return u.ErrVersionConflict
+}
+
+// DeleteHousehold removes a household the user owns. The accounts, budgets and
+// transactions shared with it become private to their owners again.
+func (s *HouseholdStorage) DeleteHousehold(ctx context.Context, householdID string) error {
+	objID, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return apperr.InvalidArgument("household_id", "invalid household ID: %v", err)
+	}
+
+	result, err := s.db.Collection("households").DeleteOne(ctx, member(ctx, bson.M{"_id": objID}, true))
+	if err != nil {
+		log.Printf("Failed to delete household: %v", err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return apperr.NotFound("household", householdID)
+	}
+
+	update := bson.M{"$set": bson.M{"household_id": ""}, "$inc": bumpVersion}
+	for name := range sharedCollections {
+		if _, err := s.db.Collection(name).UpdateMany(ctx, bson.M{"household_id": householdID}, update); err != nil {
+			log.Printf("Failed to unshare %s: %v", name, err)
+			return err
+		}
+	}
+	return nil
+}
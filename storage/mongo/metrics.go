@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"budget-service/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// commandMonitor times every command the driver sends by collection and command
+// name. The collection is only named when the command starts, so it is kept
+// until the command finishes.
+func commandMonitor() *event.CommandMonitor {
+	var collections sync.Map // request ID -> collection
+	finished := func(requestID int64, command string, duration time.Duration, failed bool) {
+		collection, _ := collections.LoadAndDelete(requestID)
+		name, _ := collection.(string)
+		metrics.MongoDuration.WithLabelValues(name, command).Observe(duration.Seconds())
+		if failed {
+			metrics.MongoErrors.WithLabelValues(name, command).Inc()
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			collections.Store(e.RequestID, collectionOf(e.CommandName, e.Command))
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finished(e.RequestID, e.CommandName, e.Duration, false)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finished(e.RequestID, e.CommandName, e.Duration, true)
+		},
+	}
+}
+
+// collectionOf returns the collection a command works on. Most commands name it
+// as their first value, getMore has a field of its own and commands like ping
+// have none.
+func collectionOf(command string, doc bson.Raw) string {
+	if command == "getMore" {
+		name, _ := doc.Lookup("collection").StringValueOK()
+		return name
+	}
+	name, _ := doc.Lookup(command).StringValueOK()
+	return name
+}
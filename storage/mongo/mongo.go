@@ -11,37 +11,78 @@ import (
 )
 
 type MongoStorage struct {
-	Db    *mongo.Database
-	Accounts u.AccountStorage
-	Budgets u.BudgetStorage
-	Categorys u.CategoryStorage
-	Goals u.GoalStorage
-	Transactions u.TransactionStorage
-	Notifications u.NotificationService
+	Db              *mongo.Database
+	Accounts        u.AccountStorage
+	Budgets         u.BudgetStorage
+	Categorys       u.CategoryStorage
+	Goals           u.GoalStorage
+	Transactions    u.TransactionStorage
+	Notifications   u.NotificationService
+	ExchangeRates   u.ExchangeRateStorage
+	NetWorths       u.NetWorthStorage
+	Reconciliations u.ReconciliationStorage
+	Trashes         u.TrashStorage
+	AuditLogs       u.AuditLogStorage
+	Settings        u.UserSettingsStorage
+	Households      u.HouseholdStorage
+	Splits          u.SplitStorage
 }
 
-func NewMongoConnection() (*MongoStorage, error) {
-	uri := fmt.Sprintf("mongodb://%s:%d", "localhost", 27017)
+// NewMongoConnection connects to the MongoDB server at uri and prepares database for use
+func NewMongoConnection(uri, database string) (*MongoStorage, error) {
+	ctx := context.Background()
+	clientOptions := options.Client().ApplyURI(uri).SetMonitor(commandMonitor())
 
-	clientOptions := options.Client().ApplyURI(uri)
-
-	client, err := mongo.Connect(context.Background(), clientOptions)
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("connect to mongo: %w", err)
 	}
-
-	err = client.Ping(context.Background(), nil)
-	if err != nil {
-		log.Fatal("Error: Couldn't connect to the database.", err)
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		return nil, fmt.Errorf("ping mongo: %w", err)
 	}
+	log.Printf("Connected to MongoDB")
 
-	fmt.Println("Connected to MongoDB!")
+	db := client.Database(database)
+	if err := prepare(ctx, db); err != nil {
+		client.Disconnect(ctx)
+		return nil, err
+	}
+	return &MongoStorage{Db: db}, nil
+}
 
-	db := client.Database("budget")
+// prepare runs the seeding, backfills and indexes the storages rely on
+func prepare(ctx context.Context, db *mongo.Database) error {
+	if err := EnsureCategoryTemplates(ctx, db); err != nil {
+		return fmt.Errorf("seed category templates: %w", err)
+	}
+	if err := BackfillOpeningBalances(ctx, db); err != nil {
+		return fmt.Errorf("backfill opening balances: %w", err)
+	}
+	if err := EnsureVersions(ctx, db); err != nil {
+		return fmt.Errorf("backfill document versions: %w", err)
+	}
+	if err := MigrateStringDates(ctx, db); err != nil {
+		return fmt.Errorf("migrate string dates: %w", err)
+	}
+	if err := EnsureUserSettingsIndex(ctx, db); err != nil {
+		return fmt.Errorf("index user settings: %w", err)
+	}
+	if err := EnsureHouseholdIndex(ctx, db); err != nil {
+		return fmt.Errorf("index households: %w", err)
+	}
+	return nil
+}
 
-	return &MongoStorage{Db: db}, err
+// Ping checks that the MongoDB server answers
+func (s *MongoStorage) Ping(ctx context.Context) error {
+	return s.Db.Client().Ping(ctx, nil)
 }
 
+// Close disconnects from MongoDB, waiting for operations in progress until ctx is done
+func (s *MongoStorage) Close(ctx context.Context) error {
+	return s.Db.Client().Disconnect(ctx)
+}
 
 func (s *MongoStorage) Account() u.AccountStorage {
 	if s.Accounts == nil {
@@ -84,3 +125,59 @@ func (s *MongoStorage) Notification() u.NotificationService {
 	}
 	return s.Notifications
 }
+
+func (s *MongoStorage) ExchangeRate() u.ExchangeRateStorage {
+	if s.ExchangeRates == nil {
+		s.ExchangeRates = &ExchangeRateStorage{s.Db}
+	}
+	return s.ExchangeRates
+}
+
+func (s *MongoStorage) NetWorth() u.NetWorthStorage {
+	if s.NetWorths == nil {
+		s.NetWorths = &NetWorthStorage{s.Db}
+	}
+	return s.NetWorths
+}
+
+func (s *MongoStorage) Reconciliation() u.ReconciliationStorage {
+	if s.Reconciliations == nil {
+		s.Reconciliations = &ReconciliationStorage{s.Db}
+	}
+	return s.Reconciliations
+}
+
+func (s *MongoStorage) Trash() u.TrashStorage {
+	if s.Trashes == nil {
+		s.Trashes = &TrashStorage{s.Db}
+	}
+	return s.Trashes
+}
+
+func (s *MongoStorage) AuditLog() u.AuditLogStorage {
+	if s.AuditLogs == nil {
+		s.AuditLogs = &AuditLogStorage{s.Db}
+	}
+	return s.AuditLogs
+}
+
+func (s *MongoStorage) UserSettings() u.UserSettingsStorage {
+	if s.Settings == nil {
+		s.Settings = &UserSettingsStorage{s.Db}
+	}
+	return s.Settings
+}
+
+func (s *MongoStorage) Household() u.HouseholdStorage {
+	if s.Households == nil {
+		s.Households = &HouseholdStorage{s.Db}
+	}
+	return s.Households
+}
+
+func (s *MongoStorage) Split() u.SplitStorage {
+	if s.Splits == nil {
+		s.Splits = &SplitStorage{s.Db}
+	}
+	return s.Splits
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	pb "budget-service/genproto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NetWorthStorage keeps one net worth snapshot per user and day
+type NetWorthStorage struct {
+	db *mongo.Database
+}
+
+// NewNetWorthStorage initializes a new NetWorthStorage
+func NewNetWorthStorage(db *mongo.Database) *NetWorthStorage {
+	return &NetWorthStorage{db: db}
+}
+
+type netWorthSnapshot struct {
+	UserId      string  `bson:"user_id"`
+	Date        string  `bson:"date"`
+	Assets      float64 `bson:"assets"`
+	Liabilities float64 `bson:"liabilities"`
+	NetWorth    float64 `bson:"net_worth"`
+	Currency    string  `bson:"currency"`
+}
+
+// SaveSnapshot stores the snapshot, replacing an earlier one for the same user and day
+func (s *NetWorthStorage) SaveSnapshot(ctx context.Context, snapshot *pb.NetWorthSnapshot) error {
+	coll := s.db.Collection("net_worth_snapshots")
+
+	filter := bson.M{"user_id": snapshot.UserId, "date": snapshot.Date}
+	_, err := coll.ReplaceOne(ctx, filter, netWorthSnapshot{
+		UserId:      snapshot.UserId,
+		Date:        snapshot.Date,
+		Assets:      snapshot.Assets,
+		Liabilities: snapshot.Liabilities,
+		NetWorth:    snapshot.NetWorth,
+		Currency:    snapshot.Currency,
+	}, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("Failed to save net worth snapshot: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetNetWorthHistory returns the user's snapshots ordered by date
+func (s *NetWorthStorage) GetNetWorthHistory(ctx context.Context, req *pb.GetNetWorthHistoryRequest) (*pb.NetWorthHistoryResponse, error) {
+	coll := s.db.Collection("net_worth_snapshots")
+
+	filter := bson.M{"user_id": req.UserId}
+	date := bson.M{}
+	if req.StartDate != "" {
+		date["$gte"] = req.StartDate
+	}
+	if req.EndDate != "" {
+		date["$lte"] = req.EndDate
+	}
+	if len(date) > 0 {
+		filter["date"] = date
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := coll.Find(ctx, owned(ctx, filter), opts)
+	if err != nil {
+		log.Printf("Failed to list net worth snapshots: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []*pb.NetWorthSnapshot
+	for cursor.Next(ctx) {
+		var data netWorthSnapshot
+		if err := cursor.Decode(&data); err != nil {
+			log.Printf("Failed to decode net worth snapshot: %v", err)
+			return nil, err
+		}
+		snapshots = append(snapshots, &pb.NetWorthSnapshot{
+			UserId:      data.UserId,
+			Date:        data.Date,
+			Assets:      data.Assets,
+			Liabilities: data.Liabilities,
+			NetWorth:    data.NetWorth,
+			Currency:    data.Currency,
+		})
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+
+	return &pb.NetWorthHistoryResponse{Snapshots: snapshots}, nil
+}
@@ -2,9 +2,10 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"log"
+	"time"
 
+	"budget-service/apperr"
 	pb "budget-service/genproto"
 	"budget-service/model"
 
@@ -21,15 +22,16 @@ func NewNotificationService(db *mongo.Database) *NotificationService {
 	return &NotificationService{db: db}
 }
 
-func (s *NotificationService) CreateNotification(req model.Send) error {
+func (s *NotificationService) CreateNotification(ctx context.Context, req model.Send) error {
 	coll := s.db.Collection("notifications")
 	// Generate a new ObjectID for the notification
 	objID := primitive.NewObjectID()
 
-	_, err := coll.InsertOne(context.Background(), bson.M{
+	_, err := coll.InsertOne(ctx, bson.M{
 		"_id":     objID, // Use ObjectID for _id
-		"user_id":  req.UserId,
+		"user_id": req.UserId,
 		"message": req.Message,
+		"version": 1,
 	})
 	if err != nil {
 		log.Printf("Failed to create notification: %v", err)
@@ -38,47 +40,78 @@ func (s *NotificationService) CreateNotification(req model.Send) error {
 	return nil
 }
 
-// GetNotification retrieves a notification by user_id
-func (s *NotificationService) GetNotification(req *pb.GetNotificationByidRequest) (*pb.GetNotificationByidResponse, error) {
+// notificationFilter selects a notification by its ID, or the user's notification when no ID is given
+func notificationFilter(req *pb.GetNotificationByidRequest) (bson.M, error) {
+	if req.NotificationId == "" {
+		return bson.M{"user_id": req.UserId}, nil
+	}
+	objID, err := primitive.ObjectIDFromHex(req.NotificationId)
+	if err != nil {
+		return nil, apperr.InvalidArgument("notification_id", "invalid notification ID: %v", err)
+	}
+	return bson.M{"_id": objID}, nil
+}
+
+// GetNotification retrieves a notification by notification_id or user_id
+func (s *NotificationService) GetNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.GetNotificationByidResponse, error) {
 	coll := s.db.Collection("notifications")
+	filter, err := notificationFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
 	var notificationData struct {
 		ID      primitive.ObjectID `bson:"_id"` // BSON tag for ID field
-		UserId  string             `bson:"user_Id"`
+		UserId  string             `bson:"user_id"`
 		Message string             `bson:"message"`
+		Version int64              `bson:"version"`
 	}
-	err := coll.FindOne(context.Background(), bson.M{"user_id": req.UserId}).Decode(&notificationData)
+	err = coll.FindOne(ctx, notDeleted(owned(ctx, filter))).Decode(&notificationData)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("notification not found")
+			return nil, apperr.NotFound("notification", req.NotificationId)
 		}
 		log.Printf("Failed to retrieve notification: %v", err)
 		return nil, err
 	}
 
 	return &pb.GetNotificationByidResponse{
-		UserId:  notificationData.UserId,
-		Message: notificationData.Message,
+		NotificationId: notificationData.ID.Hex(),
+		UserId:         notificationData.UserId,
+		Message:        notificationData.Message,
+		Version:        notificationData.Version,
 	}, nil
 }
 
-// DeleteNotification deletes a notification by user_id
-func (s *NotificationService) DeleteNotification(req *pb.GetNotificationByidRequest) (*pb.NotificationsResponse, error) {
+// DeleteNotification moves a notification to the trash by notification_id or user_id
+func (s *NotificationService) DeleteNotification(ctx context.Context, req *pb.GetNotificationByidRequest) (*pb.NotificationsResponse, error) {
 	coll := s.db.Collection("notifications")
-	res, err := coll.DeleteOne(context.Background(), bson.M{"user_Id": req.UserId})
+	filter, err := notificationFilter(req)
 	if err != nil {
-		log.Printf("Failed to delete notification: %v", err)
-		return &pb.NotificationsResponse{
-			Message: "Error while deleting notification",
-			Success: false,
-		}, err
+		return &pb.NotificationsResponse{Message: "Invalid notification ID", Success: false}, err
 	}
 
-	if res.DeletedCount == 0 {
+	// Without an ID only the first matching notification is deleted, as before
+	var target struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	err = coll.FindOne(ctx, notDeleted(owned(ctx, filter))).Decode(&target)
+	if err == mongo.ErrNoDocuments {
 		return &pb.NotificationsResponse{
 			Message: "No notification found to delete",
 			Success: false,
 		}, nil
 	}
+	if err == nil {
+		_, err = softDelete(ctx, coll, bson.M{"_id": target.ID}, time.Now())
+	}
+	if err != nil {
+		log.Printf("Failed to delete notification: %v", err)
+		return &pb.NotificationsResponse{
+			Message: "Error while deleting notification",
+			Success: false,
+		}, err
+	}
 
 	return &pb.NotificationsResponse{
 		Message: "Notification deleted successfully",
@@ -86,28 +119,41 @@ func (s *NotificationService) DeleteNotification(req *pb.GetNotificationByidRequ
 	}, nil
 }
 
+// RestoreNotification takes a deleted notification out of the trash
+func (s *NotificationService) RestoreNotification(ctx context.Context, notificationID string) error {
+	_, err := restore(ctx, s.db.Collection("notifications"), notificationID)
+	if err != nil {
+		log.Printf("Failed to restore notification: %v", err)
+		return err
+	}
+	return nil
+}
+
 // ListNotification lists all notifications
-func (s *NotificationService) ListNotification(req *pb.Void) (*pb.ListNotificationResponse, error) {
+func (s *NotificationService) ListNotification(ctx context.Context, req *pb.Void) (*pb.ListNotificationResponse, error) {
 	coll := s.db.Collection("notifications")
-	cursor, err := coll.Find(context.Background(), bson.M{})
+	cursor, err := coll.Find(ctx, notDeleted(owned(ctx, bson.M{})))
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(ctx)
 
 	var notifications []*pb.GetNotificationByidResponse
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var notificationData struct {
 			ID      primitive.ObjectID `bson:"_id"`
 			UserId  string             `bson:"user_id"`
 			Message string             `bson:"message"`
+			Version int64              `bson:"version"`
 		}
 		if err := cursor.Decode(&notificationData); err != nil {
 			return nil, err
 		}
 		notifications = append(notifications, &pb.GetNotificationByidResponse{
-			UserId:  notificationData.UserId,
-			Message: notificationData.Message,
+			NotificationId: notificationData.ID.Hex(),
+			UserId:         notificationData.UserId,
+			Message:        notificationData.Message,
+			Version:        notificationData.Version,
 		})
 	}
 
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+
+	"budget-service/appctx"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// owned restricts filter to the documents of the user the request acts for, so an
+// ID belonging to someone else reads as not found. Calls that aren't limited to one
+// user, such as scheduled jobs and admin tools, see every document.
+func owned(ctx context.Context, filter bson.M) bson.M {
+	if userID := appctx.UserID(ctx); userID != "" {
+		filter["user_id"] = userID
+	}
+	return filter
+}
+
+// sharedCollections hold documents that can be shared with a household through their household_id
+var sharedCollections = map[string]bool{"accounts": true, "budgets": true, "transactions": true}
+
+// editorRoles are the household roles that may change shared documents
+var editorRoles = []string{"owner", "editor"}
+
+// scoped is owned for collections whose documents can be shared with a household.
+// Besides their own documents users reach those shared with every household they
+// belong to, or with write set, the households where they are an owner or editor.
+func scoped(ctx context.Context, coll *mongo.Collection, filter bson.M, write bool) (bson.M, error) {
+	userID := appctx.UserID(ctx)
+	if userID == "" || !sharedCollections[coll.Name()] {
+		return owned(ctx, filter), nil
+	}
+	reach, err := reachable(ctx, coll.Database(), userID, write)
+	if err != nil {
+		return nil, err
+	}
+	return and(filter, reach), nil
+}
+
+// reachable matches the documents userID owns or can reach through their households
+func reachable(ctx context.Context, db *mongo.Database, userID string, write bool) (bson.M, error) {
+	households, err := householdsOf(ctx, db, userID, write)
+	if err != nil {
+		return nil, err
+	}
+	if len(households) == 0 {
+		return bson.M{"user_id": userID}, nil
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{"user_id": userID},
+		bson.M{"household_id": bson.M{"$in": households}},
+	}}, nil
+}
+
+// householdsOf returns the ids of the households userID is a member of, or with
+// write set, those where they are an owner or editor
+func householdsOf(ctx context.Context, db *mongo.Database, userID string, write bool) ([]string, error) {
+	member := bson.M{"user_id": userID}
+	if write {
+		member["role"] = bson.M{"$in": editorRoles}
+	}
+	filter := bson.M{"members": bson.M{"$elemMatch": member}}
+
+	cursor, err := db.Collection("households").Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []string
+	for cursor.Next(ctx) {
+		var doc householdDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID.Hex())
+	}
+	return ids, cursor.Err()
+}
+
+// and adds cond to filter without overwriting conditions on the same fields
+func and(filter, cond bson.M) bson.M {
+	conds, _ := filter["$and"].(bson.A)
+	filter["$and"] = append(conds, cond)
+	return filter
+}
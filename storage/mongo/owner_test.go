@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"budget-service/appctx"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// offlineDB returns a database handle that never reaches a server. The filter
+// builders only need collection names unless they look up households.
+func offlineDB(t *testing.T) *mongo.Database {
+	t.Helper()
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	return client.Database("budget")
+}
+
+func TestOwned(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		filter bson.M
+		want   bson.M
+	}{
+		{
+			name:   "unscoped call sees everything",
+			filter: bson.M{"_id": "a1"},
+			want:   bson.M{"_id": "a1"},
+		},
+		{
+			name:   "scoped call sees its own",
+			userID: "ann",
+			filter: bson.M{"_id": "a1"},
+			want:   bson.M{"_id": "a1", "user_id": "ann"},
+		},
+		{
+			name:   "a user_id in the request can't widen the scope",
+			userID: "ann",
+			filter: bson.M{"user_id": "bob"},
+			want:   bson.M{"user_id": "ann"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := appctx.WithUserID(context.Background(), tt.userID)
+			if got := owned(ctx, tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("owned = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopedWithoutHouseholds(t *testing.T) {
+	db := offlineDB(t)
+	tests := []struct {
+		name       string
+		collection string
+		userID     string
+		want       bson.M
+	}{
+		{
+			name:       "unscoped call on a shared collection",
+			collection: "accounts",
+			want:       bson.M{"_id": "x"},
+		},
+		{
+			name:       "private collection is owned",
+			collection: "notifications",
+			userID:     "ann",
+			want:       bson.M{"_id": "x", "user_id": "ann"},
+		},
+		{
+			name:       "goals aren't shared",
+			collection: "goals",
+			userID:     "ann",
+			want:       bson.M{"_id": "x", "user_id": "ann"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := appctx.WithUserID(context.Background(), tt.userID)
+			for _, write := range []bool{false, true} {
+				got, err := scoped(ctx, db.Collection(tt.collection), bson.M{"_id": "x"}, write)
+				if err != nil {
+					t.Fatalf("scoped: %v", err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("scoped(write=%v) = %v, want %v", write, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnd(t *testing.T) {
+	filter := bson.M{"user_id": "ann"}
+	filter = and(filter, bson.M{"$or": bson.A{bson.M{"a": 1}}})
+	filter = and(filter, bson.M{"$or": bson.A{bson.M{"b": 2}}})
+
+	want := bson.M{
+		"user_id": "ann",
+		"$and":    bson.A{bson.M{"$or": bson.A{bson.M{"a": 1}}}, bson.M{"$or": bson.A{bson.M{"b": 2}}}},
+	}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("and = %v, want %v", filter, want)
+	}
+}
+
+func TestNotDeleted(t *testing.T) {
+	got := notDeleted(owned(appctx.WithUserID(context.Background(), "ann"), bson.M{"_id": "x"}))
+	want := bson.M{"_id": "x", "user_id": "ann", "deleted_at": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("notDeleted = %v, want %v", got, want)
+	}
+}
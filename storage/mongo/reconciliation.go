@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	pb "budget-service/genproto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReconciliationStorage records finished account reconciliations
+type ReconciliationStorage struct {
+	db *mongo.Database
+}
+
+// NewReconciliationStorage initializes a new ReconciliationStorage
+func NewReconciliationStorage(db *mongo.Database) *ReconciliationStorage {
+	return &ReconciliationStorage{db: db}
+}
+
+type reconciliationData struct {
+	ID                      primitive.ObjectID `bson:"_id"`
+	UserId                  string             `bson:"user_id"`
+	AccountId               string             `bson:"account_id"`
+	StatementBalance        float64            `bson:"statement_balance"`
+	StatementDate           string             `bson:"statement_date"`
+	ClearedBalance          float64            `bson:"cleared_balance"`
+	Adjustment              float64            `bson:"adjustment"`
+	AdjustmentTransactionId string             `bson:"adjustment_transaction_id"`
+	CreatedAt               string             `bson:"created_at"`
+}
+
+// CreateReconciliation stores the reconciliation and sets its generated ID
+func (s *ReconciliationStorage) CreateReconciliation(ctx context.Context, rec *pb.Reconciliation) error {
+	coll := s.db.Collection("reconciliations")
+
+	objID := primitive.NewObjectID()
+	_, err := coll.InsertOne(ctx, reconciliationData{
+		ID:                      objID,
+		UserId:                  rec.UserId,
+		AccountId:               rec.AccountId,
+		StatementBalance:        rec.StatementBalance,
+		StatementDate:           rec.StatementDate,
+		ClearedBalance:          rec.ClearedBalance,
+		Adjustment:              rec.Adjustment,
+		AdjustmentTransactionId: rec.AdjustmentTransactionId,
+		CreatedAt:               rec.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("Failed to create reconciliation: %v", err)
+		return err
+	}
+
+	rec.ReconciliationId = objID.Hex()
+	return nil
+}
+
+// ListReconciliations lists an account's reconciliations, newest statement first
+func (s *ReconciliationStorage) ListReconciliations(ctx context.Context, req *pb.ListReconciliationsRequest) (*pb.ListReconciliationsResponse, error) {
+	coll := s.db.Collection("reconciliations")
+
+	opts := options.Find().SetSort(bson.D{{Key: "statement_date", Value: -1}})
+	cursor, err := coll.Find(ctx, owned(ctx, bson.M{"account_id": req.AccountId}), opts)
+	if err != nil {
+		log.Printf("Failed to list reconciliations: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reconciliations []*pb.Reconciliation
+	for cursor.Next(ctx) {
+		var data reconciliationData
+		if err := cursor.Decode(&data); err != nil {
+			log.Printf("Failed to decode reconciliation: %v", err)
+			return nil, err
+		}
+		reconciliations = append(reconciliations, &pb.Reconciliation{
+			ReconciliationId:        data.ID.Hex(),
+			UserId:                  data.UserId,
+			AccountId:               data.AccountId,
+			StatementBalance:        data.StatementBalance,
+			StatementDate:           data.StatementDate,
+			ClearedBalance:          data.ClearedBalance,
+			Adjustment:              data.Adjustment,
+			AdjustmentTransactionId: data.AdjustmentTransactionId,
+			CreatedAt:               data.CreatedAt,
+		})
+	}
+
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+
+	return &pb.ListReconciliationsResponse{Reconciliations: reconciliations}, nil
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"budget-service/appctx"
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	"budget-service/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDB returns a fresh database on the MongoDB server in TEST_MONGO_URI, dropped
+// when the test ends. The test is skipped when no server is configured or reachable.
+func testDB(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("TEST_MONGO_URI")
+	if uri == "" {
+		t.Skip("TEST_MONGO_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Skipf("connect to test mongo: %v", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		t.Skipf("ping test mongo: %v", err)
+	}
+
+	db := client.Database("budget_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	return db
+}
+
+// TestUserScope seeds data for one user and checks another user can't reach it
+func TestUserScope(t *testing.T) {
+	db := testDB(t)
+	const owner, other = "user-a", "user-b"
+	asOwner := appctx.WithUserID(context.Background(), owner)
+	asOther := appctx.WithUserID(context.Background(), other)
+
+	accounts := &AccountStorage{db}
+	account := &pb.CreateAccountRequest{UserId: owner, AccountName: "Checking", Type: "checking", Currency: "UZS"}
+	if _, err := accounts.CreateAccount(asOwner, account); err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+
+	budgets := &BudgetStorage{db}
+	budget := &pb.CreateBudgetRequest{UserId: owner, CategoryId: "food", Amount: 100, Period: "monthly"}
+	if _, err := budgets.CreateBudget(asOwner, budget); err != nil {
+		t.Fatalf("CreateBudget: %v", err)
+	}
+
+	transactions := &TransactionStorage{db}
+	transaction := &pb.CreateTransactionRequest{UserId: owner, AccountId: account.Id, Amount: 10, Type: "-", Currency: "UZS"}
+	if _, err := transactions.CreateTransaction(asOwner, transaction); err != nil {
+		t.Fatalf("CreateTransaction: %v", err)
+	}
+
+	notifications := &NotificationService{db}
+	if err := notifications.CreateNotification(asOwner, model.Send{UserId: owner, Message: "Your Budget is depleted"}); err != nil {
+		t.Fatalf("CreateNotification: %v", err)
+	}
+
+	t.Run("GetAccountById", func(t *testing.T) {
+		req := &pb.GetAccountByIdRequest{AccountId: account.Id}
+		if _, err := accounts.GetAccountById(asOwner, req); err != nil {
+			t.Fatalf("owner: %v", err)
+		}
+		if _, err := accounts.GetAccountById(asOther, req); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("other user: got %v, want NotFound", err)
+		}
+	})
+
+	t.Run("GetBudgetById", func(t *testing.T) {
+		req := &pb.GetBudgetByIdRequest{BudgetId: budget.Id}
+		if _, err := budgets.GetBudgetById(asOwner, req); err != nil {
+			t.Fatalf("owner: %v", err)
+		}
+		if _, err := budgets.GetBudgetById(asOther, req); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("other user: got %v, want NotFound", err)
+		}
+	})
+
+	t.Run("DeleteTransaction", func(t *testing.T) {
+		req := &pb.DeleteTransactionRequest{TransactionId: transaction.Id}
+		if _, err := transactions.DeleteTransaction(asOther, req); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("other user: got %v, want NotFound", err)
+		}
+		if _, err := transactions.GetTransactionById(asOwner, &pb.GetTransactionByIdRequest{TransactionId: transaction.Id}); err != nil {
+			t.Fatalf("owner's transaction is gone: %v", err)
+		}
+	})
+
+	t.Run("ListNotification", func(t *testing.T) {
+		resp, err := notifications.ListNotification(asOwner, &pb.Void{})
+		if err != nil {
+			t.Fatalf("owner: %v", err)
+		}
+		if len(resp.Notifications) != 1 {
+			t.Fatalf("owner: got %d notifications, want 1", len(resp.Notifications))
+		}
+
+		resp, err = notifications.ListNotification(asOther, &pb.Void{})
+		if err != nil {
+			t.Fatalf("other user: %v", err)
+		}
+		if len(resp.Notifications) != 0 {
+			t.Fatalf("other user: got %d notifications, want none", len(resp.Notifications))
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"budget-service/appctx"
+	pb "budget-service/genproto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SplitStorage keeps split expenses and the settlements paying them back in MongoDB.
+// What users owe each other is derived from both.
+type SplitStorage struct {
+	db *mongo.Database
+}
+
+// NewSplitStorage initializes a new SplitStorage
+func NewSplitStorage(db *mongo.Database) *SplitStorage {
+	return &SplitStorage{db: db}
+}
+
+type splitShare struct {
+	UserID string  `bson:"user_id"`
+	Amount float64 `bson:"amount"`
+}
+
+type splitDocument struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	UserID        string             `bson:"user_id"`
+	TransactionID string             `bson:"transaction_id"`
+	HouseholdID   string             `bson:"household_id"`
+	Description   string             `bson:"description"`
+	Amount        float64            `bson:"amount"`
+	Currency      string             `bson:"currency"`
+	Date          time.Time          `bson:"date"`
+	Shares        []splitShare       `bson:"shares"`
+}
+
+type settlementDocument struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	DebtorID    string             `bson:"debtor_id"`
+	CreditorID  string             `bson:"creditor_id"`
+	Amount      float64            `bson:"amount"`
+	Currency    string             `bson:"currency"`
+	HouseholdID string             `bson:"household_id"`
+	TransferID  string             `bson:"transfer_id"`
+	Date        time.Time          `bson:"date"`
+}
+
+// involved restricts filter to the documents userFields name the user in, or that
+// belong to one of their households
+func involved(ctx context.Context, db *mongo.Database, filter bson.M, userFields ...string) (bson.M, error) {
+	userID := appctx.UserID(ctx)
+	if userID == "" {
+		return filter, nil
+	}
+	households, err := householdsOf(ctx, db, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	or := bson.A{bson.M{"household_id": bson.M{"$in": households}}}
+	for _, field := range userFields {
+		or = append(or, bson.M{field: userID})
+	}
+	return and(filter, bson.M{"$or": or}), nil
+}
+
+// CreateSplit stores a split expense and sets its ID
+func (s *SplitStorage) CreateSplit(ctx context.Context, split *pb.SplitExpense) error {
+	doc := splitDocument{
+		ID:            primitive.NewObjectID(),
+		UserID:        split.UserId,
+		TransactionID: split.TransactionId,
+		HouseholdID:   split.HouseholdId,
+		Description:   split.Description,
+		Amount:        split.Amount,
+		Currency:      split.Currency,
+		Date:          asTime(split.Date),
+	}
+	for _, share := range split.Shares {
+		doc.Shares = append(doc.Shares, splitShare{UserID: share.UserId, Amount: share.Amount})
+	}
+
+	if _, err := s.db.Collection("splits").InsertOne(ctx, doc); err != nil {
+		log.Printf("Failed to create split expense: %v", err)
+		return err
+	}
+	split.SplitId = doc.ID.Hex()
+	return nil
+}
+
+// ListSplits lists the split expenses userID paid or shares in, optionally only
+// those of one household
+func (s *SplitStorage) ListSplits(ctx context.Context, userID, householdID string) ([]*pb.SplitExpense, error) {
+	filter := bson.M{}
+	if userID != "" {
+		filter["$or"] = bson.A{bson.M{"user_id": userID}, bson.M{"shares.user_id": userID}}
+	}
+	if householdID != "" {
+		filter["household_id"] = householdID
+	}
+	filter, err := involved(ctx, s.db, filter, "user_id", "shares.user_id")
+	if err != nil {
+		log.Printf("Failed to list split expenses: %v", err)
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := s.db.Collection("splits").Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Failed to list split expenses: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var splits []*pb.SplitExpense
+	for cursor.Next(ctx) {
+		var doc splitDocument
+		if err := cursor.Decode(&doc); err != nil {
+			log.Printf("Failed to decode split expense: %v", err)
+			return nil, err
+		}
+		split := &pb.SplitExpense{
+			SplitId:       doc.ID.Hex(),
+			UserId:        doc.UserID,
+			TransactionId: doc.TransactionID,
+			HouseholdId:   doc.HouseholdID,
+			Description:   doc.Description,
+			Amount:        doc.Amount,
+			Currency:      doc.Currency,
+			Date:          timestamp(doc.Date),
+		}
+		for _, share := range doc.Shares {
+			split.Shares = append(split.Shares, &pb.SplitShare{UserId: share.UserID, Amount: share.Amount})
+		}
+		splits = append(splits, split)
+	}
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+	return splits, nil
+}
+
+// CreateSettlement stores a settlement and sets its ID
+func (s *SplitStorage) CreateSettlement(ctx context.Context, settlement *pb.Settlement) error {
+	doc := settlementDocument{
+		ID:          primitive.NewObjectID(),
+		DebtorID:    settlement.DebtorId,
+		CreditorID:  settlement.CreditorId,
+		Amount:      settlement.Amount,
+		Currency:    settlement.Currency,
+		HouseholdID: settlement.HouseholdId,
+		TransferID:  settlement.TransferId,
+		Date:        asTime(settlement.Date),
+	}
+	if _, err := s.db.Collection("settlements").InsertOne(ctx, doc); err != nil {
+		log.Printf("Failed to create settlement: %v", err)
+		return err
+	}
+	settlement.SettlementId = doc.ID.Hex()
+	return nil
+}
+
+// ListSettlements lists the settlements userID paid or received, optionally only
+// those of one household
+func (s *SplitStorage) ListSettlements(ctx context.Context, userID, householdID string) ([]*pb.Settlement, error) {
+	filter := bson.M{}
+	if userID != "" {
+		filter["$or"] = bson.A{bson.M{"debtor_id": userID}, bson.M{"creditor_id": userID}}
+	}
+	if householdID != "" {
+		filter["household_id"] = householdID
+	}
+	filter, err := involved(ctx, s.db, filter, "debtor_id", "creditor_id")
+	if err != nil {
+		log.Printf("Failed to list settlements: %v", err)
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := s.db.Collection("settlements").Find(ctx, filter, opts)
+	if err != nil {
+		log.Printf("Failed to list settlements: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var settlements []*pb.Settlement
+	for cursor.Next(ctx) {
+		var doc settlementDocument
+		if err := cursor.Decode(&doc); err != nil {
+			log.Printf("Failed to decode settlement: %v", err)
+			return nil, err
+		}
+		settlements = append(settlements, &pb.Settlement{
+			SettlementId: doc.ID.Hex(),
+			DebtorId:     doc.DebtorID,
+			CreditorId:   doc.CreditorID,
+			Amount:       doc.Amount,
+			Currency:     doc.Currency,
+			HouseholdId:  doc.HouseholdID,
+			TransferId:   doc.TransferID,
+			Date:         timestamp(doc.Date),
+		})
+	}
+	if err := cursor.Err(); err != nil {
+		log.Printf("Cursor error: %v", err)
+		return nil, err
+	}
+	return settlements, nil
+}
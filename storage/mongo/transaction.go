@@ -2,9 +2,10 @@ package storage
 
 import (
 	"context"
-	"fmt"
 	"log"
+	"time"
 
+	"budget-service/apperr"
 	pb "budget-service/genproto"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -22,23 +23,79 @@ func NewTransactionStorage(db *mongo.Database) *TransactionStorage {
 	return &TransactionStorage{db: db}
 }
 
+// transactionDocument is how a transaction is stored in the transactions collection.
+// Amount is in the account's currency; a transaction entered in another currency
+// keeps what was entered in OriginalAmount and OriginalCurrency.
+type transactionDocument struct {
+	ID               primitive.ObjectID `bson:"_id"`
+	UserID           string             `bson:"user_id"`
+	AccountID        string             `bson:"account_id"`
+	CategoryID       string             `bson:"category_id"`
+	Amount           float32            `bson:"amount"`
+	Type             string             `bson:"type"`
+	Description      string             `bson:"description"`
+	Date             time.Time          `bson:"date"`
+	TimeZone         string             `bson:"time_zone"`
+	Currency         string             `bson:"currency"`
+	OriginalAmount   float32            `bson:"original_amount"`
+	OriginalCurrency string             `bson:"original_currency"`
+	ExchangeRate     float64            `bson:"exchange_rate"`
+	Cleared          bool               `bson:"cleared"`
+	Reconciled       bool               `bson:"reconciled"`
+	TransferID       string             `bson:"transfer_id"`
+	HouseholdID      string             `bson:"household_id"`
+	Version          int64              `bson:"version"`
+}
+
+func (t transactionDocument) toProto() *pb.TransactionResponse {
+	return &pb.TransactionResponse{
+		TransactionId:    t.ID.Hex(),
+		UserId:           t.UserID,
+		AccountId:        t.AccountID,
+		CategoryId:       t.CategoryID,
+		Amount:           t.Amount,
+		Type:             t.Type,
+		Description:      t.Description,
+		Date:             timestamp(t.Date),
+		TimeZone:         t.TimeZone,
+		Currency:         t.Currency,
+		OriginalAmount:   t.OriginalAmount,
+		OriginalCurrency: t.OriginalCurrency,
+		ExchangeRate:     t.ExchangeRate,
+		Cleared:          t.Cleared,
+		Reconciled:       t.Reconciled,
+		TransferId:       t.TransferID,
+		HouseholdId:      t.HouseholdID,
+		Version:          t.Version,
+	}
+}
+
 // CreateTransaction creates a new transaction in the database
-func (s *TransactionStorage) CreateTransaction(req *pb.CreateTransactionRequest) (*pb.Response, error) {
+func (s *TransactionStorage) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.Response, error) {
 	coll := s.db.Collection("transactions")
 
 	// Generate a new ObjectID for the transaction
 	objID := primitive.NewObjectID()
 	req.Id = objID.Hex() // Set the ID field in the request
 
-	_, err := coll.InsertOne(context.Background(), bson.M{
-		"_id":          objID, // Use ObjectID for _id
-		"user_id":     req.UserId,
-		"account_id":  req.AccountId,
-		"category_id": req.CategoryId,
-		"amount":      req.Amount,
-		"type":        req.Type,
-		"description": req.Description,
-		"date":        req.Date,
+	_, err := coll.InsertOne(ctx, bson.M{
+		"_id":               objID, // Use ObjectID for _id
+		"user_id":           req.UserId,
+		"account_id":        req.AccountId,
+		"category_id":       req.CategoryId,
+		"amount":            req.Amount,
+		"type":              req.Type,
+		"description":       req.Description,
+		"date":              asTime(req.Date),
+		"time_zone":         timeZoneOrUTC(req.TimeZone),
+		"currency":          req.Currency,
+		"original_amount":   req.OriginalAmount,
+		"original_currency": req.OriginalCurrency,
+		"exchange_rate":     req.ExchangeRate,
+		"cleared":           req.Cleared,
+		"transfer_id":       req.TransferId,
+		"household_id":      req.HouseholdId,
+		"version":           1,
 	})
 	if err != nil {
 		log.Printf("Failed to create transaction: %v", err)
@@ -49,10 +106,13 @@ func (s *TransactionStorage) CreateTransaction(req *pb.CreateTransactionRequest)
 }
 
 // GetTransactions retrieves all transactions based on the filter criteria
-func (s *TransactionStorage) GetTransactions(req *pb.GetTransactionsRequest) (*pb.TransactionsResponse, error) {
+func (s *TransactionStorage) GetTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (*pb.TransactionsResponse, error) {
 	coll := s.db.Collection("transactions")
 
 	filter := bson.M{}
+	if req.UserId != "" {
+		filter["user_id"] = req.UserId
+	}
 	if req.AccountId != "" {
 		filter["account_id"] = req.AccountId
 	}
@@ -68,45 +128,43 @@ func (s *TransactionStorage) GetTransactions(req *pb.GetTransactionsRequest) (*p
 	if req.Description != "" {
 		filter["description"] = req.Description
 	}
-	if req.Date != "" {
-		filter["date"] = req.Date
+	if req.StartDate != nil || req.EndDate != nil {
+		date := bson.M{}
+		if req.StartDate != nil {
+			date["$gte"] = req.StartDate.AsTime()
+		}
+		if req.EndDate != nil {
+			date["$lt"] = req.EndDate.AsTime()
+		}
+		filter["date"] = date
+	}
+	if req.TransferId != "" {
+		filter["transfer_id"] = req.TransferId
+	}
+	if req.HouseholdId != "" {
+		filter["household_id"] = req.HouseholdId
 	}
 
-	cursor, err := coll.Find(context.Background(), filter)
+	filter, err := scoped(ctx, coll, filter, false)
 	if err != nil {
 		log.Printf("Failed to list transactions: %v", err)
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	cursor, err := coll.Find(ctx, notDeleted(filter))
+	if err != nil {
+		log.Printf("Failed to list transactions: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
 
 	var transactions []*pb.TransactionResponse
-	for cursor.Next(context.Background()) {
-		var transactionData struct {
-			ID          primitive.ObjectID `bson:"_id"` // BSON tag for ID field
-			UserId      string             `bson:"user_id"`
-			AccountId   string             `bson:"account_id"`
-			CategoryId  string             `bson:"category_id"`
-			Amount      float32            `bson:"amount"`
-			Type        string             `bson:"type"`
-			Description string             `bson:"description"`
-			Date        string             `bson:"date"`
-		}
+	for cursor.Next(ctx) {
+		var transactionData transactionDocument
 		if err := cursor.Decode(&transactionData); err != nil {
 			log.Printf("Failed to decode transaction: %v", err)
 			return nil, err
 		}
-
-		transaction := &pb.TransactionResponse{
-			TransactionId: transactionData.ID.Hex(),
-			UserId:      transactionData.UserId,
-			AccountId:   transactionData.AccountId,
-			CategoryId:  transactionData.CategoryId,
-			Amount:      transactionData.Amount,
-			Type:        transactionData.Type,
-			Description: transactionData.Description,
-			Date:        transactionData.Date,
-		}
-		transactions = append(transactions, transaction)
+		transactions = append(transactions, transactionData.toProto())
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -118,55 +176,39 @@ func (s *TransactionStorage) GetTransactions(req *pb.GetTransactionsRequest) (*p
 }
 
 // GetTransactionById retrieves a transaction by its ID
-func (s *TransactionStorage) GetTransactionById(req *pb.GetTransactionByIdRequest) (*pb.TransactionResponse, error) {
+func (s *TransactionStorage) GetTransactionById(ctx context.Context, req *pb.GetTransactionByIdRequest) (*pb.TransactionResponse, error) {
 	coll := s.db.Collection("transactions")
 
 	objID, err := primitive.ObjectIDFromHex(req.TransactionId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid transaction ID: %v", err)
+		return nil, apperr.InvalidArgument("transaction_id", "invalid transaction ID: %v", err)
 	}
 
-	var transactionData struct {
-		ID          primitive.ObjectID `bson:"_id"` // BSON tag for ID field
-		UserId      string             `bson:"user_id"`
-		AccountId   string             `bson:"account_id"`
-		CategoryId  string             `bson:"category_id"`
-		Amount      float32            `bson:"amount"`
-		Type        string             `bson:"type"`
-		Description string             `bson:"description"`
-		Date        string             `bson:"date"`
-	}
+	var transactionData transactionDocument
 
-	err = coll.FindOne(context.Background(), bson.M{"_id": objID}).Decode(&transactionData)
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID}, false)
+	if err != nil {
+		return nil, err
+	}
+	err = coll.FindOne(ctx, notDeleted(filter)).Decode(&transactionData)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("transaction not found")
+			return nil, apperr.NotFound("transaction", req.TransactionId)
 		}
 		log.Printf("Failed to get transaction by ID: %v", err)
 		return nil, err
 	}
 
-	transaction := &pb.TransactionResponse{
-		TransactionId: transactionData.ID.Hex(),
-		UserId:      transactionData.UserId,
-		AccountId:   transactionData.AccountId,
-		CategoryId:  transactionData.CategoryId,
-		Amount:      transactionData.Amount,
-		Type:        transactionData.Type,
-		Description: transactionData.Description,
-		Date:        transactionData.Date,
-	}
-
-	return transaction, nil
+	return transactionData.toProto(), nil
 }
 
 // UpdateTransaction updates a transaction based on the provided request data
-func (s *TransactionStorage) UpdateTransaction(req *pb.UpdateTransactionRequest) (*pb.Response, error) {
+func (s *TransactionStorage) UpdateTransaction(ctx context.Context, req *pb.UpdateTransactionRequest) (*pb.Response, error) {
 	coll := s.db.Collection("transactions")
 
 	objID, err := primitive.ObjectIDFromHex(req.TransactionId)
 	if err != nil {
-		return &pb.Response{Message: "Invalid transaction ID"}, err
+		return &pb.Response{Message: "Invalid transaction ID"}, apperr.InvalidArgument("transaction_id", "invalid transaction ID: %v", err)
 	}
 
 	update := bson.M{}
@@ -185,15 +227,20 @@ func (s *TransactionStorage) UpdateTransaction(req *pb.UpdateTransactionRequest)
 	if req.Description != "" {
 		update["description"] = req.Description
 	}
-	if req.Date != "" {
-		update["date"] = req.Date
+	if req.Date != nil {
+		update["date"] = req.Date.AsTime()
+	}
+	if req.TimeZone != "" {
+		update["time_zone"] = req.TimeZone
 	}
 
 	if len(update) == 0 {
 		return &pb.Response{Message: "Nothing to update"}, nil
 	}
 
-	_, err = coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{"$set": update})
+	// Reconciled transactions back a statement balance, so they stay as they are
+	unmet := apperr.FailedPrecondition("not_reconciled", "transaction %s is reconciled and can't be changed", req.TransactionId)
+	err = updateVersionedIf(ctx, coll, objID, req.Version, bson.M{"reconciled": bson.M{"$ne": true}}, unmet, bson.M{"$set": update}, "transaction")
 	if err != nil {
 		log.Printf("Failed to update transaction: %v", err)
 		return &pb.Response{Message: "Failed to update transaction"}, err
@@ -202,20 +249,149 @@ func (s *TransactionStorage) UpdateTransaction(req *pb.UpdateTransactionRequest)
 	return &pb.Response{Message: "Transaction updated successfully"}, nil
 }
 
-// DeleteTransaction deletes a transaction by its ID
-func (s *TransactionStorage) DeleteTransaction(req *pb.DeleteTransactionRequest) (*pb.TransactionDeleteResponse, error) {
+// DeleteTransaction moves a transaction to the trash
+func (s *TransactionStorage) DeleteTransaction(ctx context.Context, req *pb.DeleteTransactionRequest) (*pb.TransactionDeleteResponse, error) {
 	coll := s.db.Collection("transactions")
 
 	objID, err := primitive.ObjectIDFromHex(req.TransactionId)
 	if err != nil {
-		return &pb.TransactionDeleteResponse{Success: false}, fmt.Errorf("invalid transaction ID: %v", err)
+		return &pb.TransactionDeleteResponse{Success: false}, apperr.InvalidArgument("transaction_id", "invalid transaction ID: %v", err)
 	}
 
-	_, err = coll.DeleteOne(context.Background(), bson.M{"_id": objID})
+	deleted, err := softDelete(ctx, coll, bson.M{"_id": objID}, time.Now())
 	if err != nil {
 		log.Printf("Failed to delete transaction: %v", err)
 		return &pb.TransactionDeleteResponse{Success: false}, err
 	}
+	if deleted == 0 {
+		return &pb.TransactionDeleteResponse{Success: false}, apperr.NotFound("transaction", req.TransactionId)
+	}
 
 	return &pb.TransactionDeleteResponse{Success: true}, nil
 }
+
+// RestoreTransaction takes a deleted transaction out of the trash, together with
+// the other side when it is part of a transfer. Transactions of a deleted account
+// come back only by restoring the account.
+func (s *TransactionStorage) RestoreTransaction(ctx context.Context, transactionID string) error {
+	coll := s.db.Collection("transactions")
+
+	objID, err := primitive.ObjectIDFromHex(transactionID)
+	if err != nil {
+		return apperr.InvalidArgument("transaction_id", "invalid transaction ID: %v", err)
+	}
+
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}, true)
+	if err != nil {
+		return err
+	}
+	var deleted struct {
+		TransferId string `bson:"transfer_id"`
+	}
+	if err := coll.FindOne(ctx, filter).Decode(&deleted); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return apperr.NotFound("deleted transaction", transactionID)
+		}
+		log.Printf("Failed to restore transaction: %v", err)
+		return err
+	}
+	if deleted.TransferId != "" {
+		filter, err = scoped(ctx, coll, bson.M{"transfer_id": deleted.TransferId, "deleted_at": bson.M{"$ne": nil}}, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	accountIds, err := coll.Distinct(ctx, "account_id", filter)
+	if err != nil {
+		log.Printf("Failed to restore transaction: %v", err)
+		return err
+	}
+	for _, id := range accountIds {
+		accountId, _ := id.(string)
+		objID, err := primitive.ObjectIDFromHex(accountId)
+		if err != nil {
+			return apperr.InvalidArgument("account_id", "invalid account ID: %v", err)
+		}
+		accounts := s.db.Collection("accounts")
+		accountFilter, err := scoped(ctx, accounts, bson.M{"_id": objID}, false)
+		if err != nil {
+			return err
+		}
+		count, err := accounts.CountDocuments(ctx, notDeleted(accountFilter))
+		if err != nil {
+			log.Printf("Failed to restore transaction: %v", err)
+			return err
+		}
+		if count == 0 {
+			return apperr.FailedPrecondition("account_not_deleted", "account %s is deleted, restore the account instead", accountId)
+		}
+	}
+
+	if _, err := coll.UpdateMany(ctx, filter, bson.M{"$unset": bson.M{"deleted_at": ""}, "$inc": bumpVersion}); err != nil {
+		log.Printf("Failed to restore transaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SetCleared marks the account's transactions as cleared or uncleared.
+// Reconciled transactions are locked and left untouched.
+func (s *TransactionStorage) SetCleared(ctx context.Context, accountId string, transactionIds []string, cleared bool) (int64, error) {
+	coll := s.db.Collection("transactions")
+
+	objIDs := make([]primitive.ObjectID, 0, len(transactionIds))
+	for _, id := range transactionIds {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return 0, apperr.InvalidArgument("transaction_id", "invalid transaction ID: %v", err)
+		}
+		objIDs = append(objIDs, objID)
+	}
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": objIDs},
+		"account_id": accountId,
+		"reconciled": bson.M{"$ne": true},
+	}
+	filter, err := scoped(ctx, coll, filter, true)
+	if err != nil {
+		return 0, err
+	}
+	result, err := coll.UpdateMany(ctx, notDeleted(filter), bson.M{"$set": bson.M{"cleared": cleared}, "$inc": bumpVersion})
+	if err != nil {
+		log.Printf("Failed to mark transactions cleared: %v", err)
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// MarkReconciled locks the given cleared transactions of the account
+func (s *TransactionStorage) MarkReconciled(ctx context.Context, accountId string, transactionIds []string) error {
+	coll := s.db.Collection("transactions")
+
+	objIDs := make([]primitive.ObjectID, 0, len(transactionIds))
+	for _, id := range transactionIds {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return apperr.InvalidArgument("transaction_id", "invalid transaction ID: %v", err)
+		}
+		objIDs = append(objIDs, objID)
+	}
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": objIDs},
+		"account_id": accountId,
+		"cleared":    true,
+	}
+	filter, err := scoped(ctx, coll, filter, true)
+	if err != nil {
+		return err
+	}
+	_, err = coll.UpdateMany(ctx, notDeleted(filter), bson.M{"$set": bson.M{"reconciled": true}, "$inc": bumpVersion})
+	if err != nil {
+		log.Printf("Failed to mark transactions reconciled: %v", err)
+		return err
+	}
+	return nil
+}
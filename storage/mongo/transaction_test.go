@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	pb "budget-service/genproto"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A document written the way CreateTransaction and MarkReconciled write it
+// decodes back into the same transaction
+func TestTransactionDocument(t *testing.T) {
+	id := primitive.NewObjectID()
+	date := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	raw, err := bson.Marshal(bson.M{
+		"_id":               id,
+		"user_id":           "user-a",
+		"account_id":        "acc-1",
+		"category_id":       "food",
+		"amount":            float32(126500),
+		"type":              "-",
+		"description":       "Lunch",
+		"date":              date,
+		"time_zone":         "Asia/Tashkent",
+		"currency":          "UZS",
+		"original_amount":   float32(10),
+		"original_currency": "USD",
+		"exchange_rate":     12650.0,
+		"cleared":           true,
+		"reconciled":        true,
+		"transfer_id":       "tr-1",
+		"household_id":      "hh-1",
+		"version":           3,
+		"deleted_at":        nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc transactionDocument
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := &pb.TransactionResponse{
+		TransactionId:    id.Hex(),
+		UserId:           "user-a",
+		AccountId:        "acc-1",
+		CategoryId:       "food",
+		Amount:           126500,
+		Type:             "-",
+		Description:      "Lunch",
+		Date:             timestamppb.New(date),
+		TimeZone:         "Asia/Tashkent",
+		Currency:         "UZS",
+		OriginalAmount:   10,
+		OriginalCurrency: "USD",
+		ExchangeRate:     12650,
+		Cleared:          true,
+		Reconciled:       true,
+		TransferId:       "tr-1",
+		HouseholdId:      "hh-1",
+		Version:          3,
+	}
+	if got := doc.toProto(); !proto.Equal(got, want) {
+		t.Errorf("toProto = %v, want %v", got, want)
+	}
+}
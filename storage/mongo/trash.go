@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"budget-service/apperr"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// trashCollections are the collections whose Delete moves documents to the trash
+var trashCollections = []string{"accounts", "budgets", "categories", "goals", "transactions", "notifications"}
+
+// notDeleted restricts filter to documents that are not in the trash.
+// A nil match also covers documents written before soft delete existed.
+func notDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// softDelete moves the documents matching filter to the trash and returns how many were moved
+func softDelete(ctx context.Context, coll *mongo.Collection, filter bson.M, deletedAt time.Time) (int64, error) {
+	filter, err := scoped(ctx, coll, filter, true)
+	if err != nil {
+		return 0, err
+	}
+	update := bson.M{"$set": bson.M{"deleted_at": deletedAt}, "$inc": bumpVersion}
+	result, err := coll.UpdateMany(ctx, notDeleted(filter), update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// restore takes the document with the given ID out of the trash
+func restore(ctx context.Context, coll *mongo.Collection, id string) (time.Time, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return time.Time{}, apperr.InvalidArgument("id", "invalid ID: %v", err)
+	}
+
+	var doc struct {
+		DeletedAt time.Time `bson:"deleted_at"`
+	}
+	filter, err := scoped(ctx, coll, bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}, true)
+	if err != nil {
+		return time.Time{}, err
+	}
+	err = coll.FindOneAndUpdate(ctx, filter, bson.M{"$unset": bson.M{"deleted_at": ""}, "$inc": bumpVersion}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return time.Time{}, apperr.NotFound("deleted item", id)
+		}
+		return time.Time{}, err
+	}
+	return doc.DeletedAt, nil
+}
+
+// TrashStorage permanently removes items that stayed in the trash too long
+type TrashStorage struct {
+	db *mongo.Database
+}
+
+// NewTrashStorage initializes a new TrashStorage
+func NewTrashStorage(db *mongo.Database) *TrashStorage {
+	return &TrashStorage{db: db}
+}
+
+// Purge deletes every trashed document that was deleted before the given time
+func (s *TrashStorage) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var total int64
+	for _, name := range trashCollections {
+		result, err := s.db.Collection(name).DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": before}})
+		if err != nil {
+			log.Printf("Failed to purge %s: %v", name, err)
+			return total, err
+		}
+		total += result.DeletedCount
+	}
+	return total, nil
+}
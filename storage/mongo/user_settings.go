@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	"budget-service/apperr"
+	pb "budget-service/genproto"
+	u "budget-service/storage"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserSettingsStorage keeps one settings document per user
+type UserSettingsStorage struct {
+	db *mongo.Database
+}
+
+// NewUserSettingsStorage initializes a new UserSettingsStorage
+func NewUserSettingsStorage(db *mongo.Database) *UserSettingsStorage {
+	return &UserSettingsStorage{db: db}
+}
+
+type userSettings struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	UserId       string             `bson:"user_id"`
+	TimeZone     string             `bson:"time_zone"`
+	BaseCurrency string             `bson:"base_currency"`
+	WeekStart    string             `bson:"week_start"`
+	Locale       string             `bson:"locale"`
+	Version      int64              `bson:"version"`
+}
+
+// EnsureUserSettingsIndex makes user_id unique, so two first writes for the same user can't both succeed
+func EnsureUserSettingsIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("user_settings").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// GetUserSettings returns the stored settings of the user
+func (s *UserSettingsStorage) GetUserSettings(ctx context.Context, userId string) (*pb.UserSettings, error) {
+	coll := s.db.Collection("user_settings")
+
+	var data userSettings
+	err := coll.FindOne(ctx, owned(ctx, bson.M{"user_id": userId})).Decode(&data)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("user settings", userId)
+		}
+		log.Printf("Failed to get user settings: %v", err)
+		return nil, err
+	}
+
+	return &pb.UserSettings{
+		UserId:       data.UserId,
+		TimeZone:     data.TimeZone,
+		BaseCurrency: data.BaseCurrency,
+		WeekStart:    data.WeekStart,
+		Locale:       data.Locale,
+		Version:      data.Version,
+	}, nil
+}
+
+// UpdateUserSettings stores the user's settings. Version 0 creates them and fails
+// with a version conflict if they already exist; otherwise the set fields are
+// updated when the stored settings are still at that version.
+func (s *UserSettingsStorage) UpdateUserSettings(ctx context.Context, req *pb.UpdateUserSettingsRequest) (*pb.UserSettings, error) {
+	coll := s.db.Collection("user_settings")
+
+	if req.Version == 0 {
+		_, err := coll.InsertOne(ctx, userSettings{
+			ID:           primitive.NewObjectID(),
+			UserId:       req.UserId,
+			TimeZone:     req.TimeZone,
+			BaseCurrency: req.BaseCurrency,
+			WeekStart:    req.WeekStart,
+			Locale:       req.Locale,
+			Version:      1,
+		})
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return nil, u.ErrVersionConflict
+			}
+			log.Printf("Failed to create user settings: %v", err)
+			return nil, err
+		}
+		return s.GetUserSettings(ctx, req.UserId)
+	}
+
+	var current userSettings
+	err := coll.FindOne(ctx, owned(ctx, bson.M{"user_id": req.UserId})).Decode(&current)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("user settings", req.UserId)
+		}
+		log.Printf("Failed to update user settings: %v", err)
+		return nil, err
+	}
+
+	update := bson.M{}
+	if req.TimeZone != "" {
+		update["time_zone"] = req.TimeZone
+	}
+	if req.BaseCurrency != "" {
+		update["base_currency"] = req.BaseCurrency
+	}
+	if req.WeekStart != "" {
+		update["week_start"] = req.WeekStart
+	}
+	if req.Locale != "" {
+		update["locale"] = req.Locale
+	}
+	if len(update) > 0 {
+		if err := updateVersioned(ctx, coll, current.ID, req.Version, update, "user settings"); err != nil {
+			log.Printf("Failed to update user settings: %v", err)
+			return nil, err
+		}
+	}
+	return s.GetUserSettings(ctx, req.UserId)
+}
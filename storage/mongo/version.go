@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"log"
+
+	"budget-service/apperr"
+	u "budget-service/storage"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// versionedCollections are the collections whose documents carry a version
+var versionedCollections = []string{"accounts", "budgets", "categories", "goals", "transactions", "notifications", "user_settings"}
+
+// bumpVersion is added to every update so each change moves the document to a new version
+var bumpVersion = bson.M{"version": 1}
+
+// updateVersioned applies set only if the document is still at the version the
+// client read, and moves it to the next version
+func updateVersioned(ctx context.Context, coll *mongo.Collection, objID primitive.ObjectID, version int64, set bson.M, entity string) error {
+	return updateVersionedIf(ctx, coll, objID, version, nil, nil, bson.M{"$set": set}, entity)
+}
+
+// updateVersionedIf is updateVersioned taking a whole update document, for
+// documents that must also match cond. It returns unmet when the document exists
+// but doesn't match.
+func updateVersionedIf(ctx context.Context, coll *mongo.Collection, objID primitive.ObjectID, version int64, cond bson.M, unmet error, update bson.M, entity string) error {
+	if version <= 0 {
+		return apperr.InvalidArgument("version", "%s version is required", entity)
+	}
+
+	filter := bson.M{"_id": objID, "version": version}
+	for k, v := range cond {
+		filter[k] = v
+	}
+	filter, err := scoped(ctx, coll, filter, true)
+	if err != nil {
+		return err
+	}
+	result, err := coll.UpdateOne(ctx, notDeleted(filter), withVersionBump(update))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	filter, err = scoped(ctx, coll, bson.M{"_id": objID}, true)
+	if err != nil {
+		return err
+	}
+	count, err := coll.CountDocuments(ctx, notDeleted(filter))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return apperr.NotFound(entity, objID.Hex())
+	}
+	if len(cond) > 0 {
+		count, err = coll.CountDocuments(ctx, notDeleted(and(filter, cond)))
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return unmet
+		}
+	}
+	return u.ErrVersionConflict
+}
+
+// withVersionBump adds bumpVersion to the update's $inc, leaving update as it is
+func withVersionBump(update bson.M) bson.M {
+	inc := bson.M{}
+	if extra, ok := update["$inc"].(bson.M); ok {
+		for k, v := range extra {
+			inc[k] = v
+		}
+	}
+	for k, v := range bumpVersion {
+		inc[k] = v
+	}
+
+	bumped := bson.M{"$inc": inc}
+	for k, v := range update {
+		if k != "$inc" {
+			bumped[k] = v
+		}
+	}
+	return bumped
+}
+
+// EnsureVersions starts documents written before versioning at version 1
+func EnsureVersions(ctx context.Context, db *mongo.Database) error {
+	for _, name := range versionedCollections {
+		filter := bson.M{"version": bson.M{"$exists": false}}
+		result, err := db.Collection(name).UpdateMany(ctx, filter, bson.M{"$set": bson.M{"version": 1}})
+		if err != nil {
+			log.Printf("Failed to backfill versions of %s: %v", name, err)
+			return err
+		}
+		if result.ModifiedCount > 0 {
+			log.Printf("Backfilled version for %d %s", result.ModifiedCount, name)
+		}
+	}
+	return nil
+}
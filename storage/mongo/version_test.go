@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestWithVersionBump(t *testing.T) {
+	tests := []struct {
+		name   string
+		update bson.M
+		want   bson.M
+	}{
+		{
+			name:   "set only",
+			update: bson.M{"$set": bson.M{"name": "Cash"}},
+			want:   bson.M{"$set": bson.M{"name": "Cash"}, "$inc": bson.M{"version": 1}},
+		},
+		{
+			name:   "with its own increments",
+			update: bson.M{"$set": bson.M{"opening_balance": 150.0}, "$inc": bson.M{"balance": 50.0}},
+			want:   bson.M{"$set": bson.M{"opening_balance": 150.0}, "$inc": bson.M{"balance": 50.0, "version": 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(tt.update)
+			got := withVersionBump(tt.update)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withVersionBump = %v, want %v", got, tt.want)
+			}
+			if len(tt.update) != before {
+				t.Errorf("withVersionBump changed its input to %v", tt.update)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package validation
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// transactionTypes are the types a client may book: "-" spends and "+" receives.
+// Transfers have their own RPC.
+var transactionTypes = []string{"+", "-"}
+
+var categoryTypes = []string{"income", "expense"}
+
+// reportPeriods are the units reports can be grouped by
+var reportPeriods = []string{"day", "week", "month", "year"}
+
+// householdRoles are the roles a household member can have
+var householdRoles = []string{"owner", "editor", "viewer"}
+
+var weekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// id requires an object ID field
+func id(field string) []Rule {
+	return []Rule{Required(field), ObjectID(field)}
+}
+
+func rulesOf(groups ...[]Rule) []Rule {
+	var all []Rule
+	for _, g := range groups {
+		all = append(all, g...)
+	}
+	return all
+}
+
+// rules declares, per request message, what a valid request looks like
+var rules = map[protoreflect.FullName][]Rule{
+	// Accounts
+	"budget.CreateAccountRequest": {
+		Required("user_id"), Required("account_name"), Required("type"), Currency("currency"),
+		NotNegative("credit_limit"), Between("statement_closing_day", 1, 31), Between("payment_due_day", 1, 31),
+		NotNegative("apr"), NotNegative("minimum_payment"), ObjectID("household_id"),
+	},
+	"budget.ListAccountsRequest":   {ObjectID("account_id"), Currency("currency"), ObjectID("household_id")},
+	"budget.GetAccountByIdRequest": id("account_id"),
+	"budget.UpdateAccountRequest": rulesOf(id("account_id"), []Rule{
+		Currency("currency"), NotNegative("credit_limit"), Between("statement_closing_day", 1, 31),
+		Between("payment_due_day", 1, 31), NotNegative("apr"), NotNegative("minimum_payment"), Positive("version"),
+	}),
+	"budget.DeleteAccountRequest":       id("account_id"),
+	"budget.ArchiveAccountRequest":      id("account_id"),
+	"budget.ReopenAccountRequest":       id("account_id"),
+	"budget.RestoreAccountRequest":      id("account_id"),
+	"budget.RecalculateBalancesRequest": {ObjectID("account_id")},
+
+	// Budgets
+	"budget.CreateBudgetRequest": {
+		Required("user_id"), Required("category_id"), ObjectID("category_id"), Positive("amount"),
+		Timestamp("start_date"), Timestamp("end_date"), TimeOrder("start_date", "end_date"), TimeZone("time_zone"),
+		ObjectID("household_id"),
+	},
+	"budget.ListBudgetsRequest": {
+		ObjectID("budget_id"), ObjectID("category_id"), Timestamp("start_date"), Timestamp("end_date"),
+		TimeOrder("start_date", "end_date"), ObjectID("household_id"),
+	},
+	"budget.GetBudgetByIdRequest": id("budget_id"),
+	"budget.UpdateBudgetRequest": rulesOf(id("budget_id"), []Rule{
+		ObjectID("category_id"), NotNegative("amount"), Timestamp("start_date"), Timestamp("end_date"),
+		TimeOrder("start_date", "end_date"), TimeZone("time_zone"), Positive("version"),
+	}),
+	"budget.DeleteBudgetRequest":  id("budget_id"),
+	"budget.RestoreBudgetRequest": id("budget_id"),
+
+	// Categories
+	"budget.CreateCategoryRequest":  {Required("user_id"), Required("name"), Required("type"), OneOf("type", categoryTypes...)},
+	"budget.ListCategoriesRequest":  {ObjectID("category_id"), OneOf("type", categoryTypes...)},
+	"budget.GetCategoryByIdRequest": id("category_id"),
+	"budget.UpdateCategoryRequest": rulesOf(id("category_id"), []Rule{
+		OneOf("type", categoryTypes...), Positive("version"),
+	}),
+	"budget.DeleteCategoryRequest":        id("category_id"),
+	"budget.RestoreCategoryRequest":       id("category_id"),
+	"budget.ListCategoryTemplatesRequest": {OneOf("type", categoryTypes...)},
+	"budget.SeedDefaultCategoriesRequest": {Required("user_id")},
+
+	// Goals
+	"budget.CreateGoalRequest": {
+		Required("user_id"), Required("name"), Positive("target_amount"), NotNegative("current_amount"),
+		RequiredTime("deadline"), Timestamp("deadline"), TimeZone("time_zone"),
+	},
+	"budget.ListGoalsRequest":   {Timestamp("deadline")},
+	"budget.GetGoalByIdRequest": id("goal_id"),
+	"budget.UpdateGoalRequest": rulesOf(id("goal_id"), []Rule{
+		NotNegative("target_amount"), NotNegative("current_amount"), Timestamp("deadline"), TimeZone("time_zone"),
+		Positive("version"),
+	}),
+	"budget.DeleteGoalRequest":  id("goal_id"),
+	"budget.RestoreGoalRequest": id("goal_id"),
+
+	// Transactions
+	"budget.CreateTransactionRequest": {
+		Required("user_id"), Required("account_id"), ObjectID("account_id"), ObjectID("category_id"),
+		Positive("amount"), Required("type"), OneOf("type", transactionTypes...),
+		RequiredTime("date"), Timestamp("date"), TimeZone("time_zone"), Currency("currency"),
+	},
+	"budget.GetTransactionsRequest": {
+		ObjectID("transaction_id"), ObjectID("account_id"), ObjectID("category_id"),
+		Timestamp("start_date"), Timestamp("end_date"), TimeOrder("start_date", "end_date"), ObjectID("household_id"),
+	},
+	"budget.GetTransactionByIdRequest": id("transaction_id"),
+	"budget.UpdateTransactionRequest": rulesOf(id("transaction_id"), []Rule{
+		ObjectID("account_id"), ObjectID("category_id"), NotNegative("amount"),
+		OneOf("type", transactionTypes...), Timestamp("date"), TimeZone("time_zone"), Positive("version"),
+	}),
+	"budget.DeleteTransactionRequest":  id("transaction_id"),
+	"budget.RestoreTransactionRequest": id("transaction_id"),
+	"budget.CreateTransferRequest": rulesOf(id("from_account_id"), id("to_account_id"), []Rule{
+		Required("user_id"), Different("from_account_id", "to_account_id"), Positive("amount"),
+		RequiredTime("date"), Timestamp("date"), TimeZone("time_zone"),
+	}),
+
+	// Notifications
+	"notifications.GetNotificationByidRequest": {ObjectID("notification_id")},
+	"notifications.RestoreNotificationRequest": id("notification_id"),
+
+	// Exchange rates
+	"budget.ExchangeRate": {
+		Required("base_currency"), Currency("base_currency"), Required("quote_currency"), Currency("quote_currency"),
+		Different("base_currency", "quote_currency"), Positive("rate"), Required("date"), Date("date"),
+	},
+	"budget.ListExchangeRatesRequest": {Currency("base_currency"), Currency("quote_currency"), Date("date")},
+	"budget.ConvertAmountRequest": {
+		Required("from_currency"), Currency("from_currency"), Required("to_currency"), Currency("to_currency"), Date("date"),
+	},
+
+	// Reports, net worth and debts
+	"budget.GetSpendingReportRequest": {
+		Required("user_id"), ObjectID("account_id"), Currency("currency"), OneOf("group_by", reportPeriods...),
+	},
+	"budget.GetIncomeReportRequest": {
+		Required("user_id"), ObjectID("account_id"), Currency("currency"), OneOf("group_by", reportPeriods...),
+	},
+	"budget.GetBudgetPerformanceReportRequest": {Required("user_id"), Currency("currency")},
+	"budget.GetGoalProgressReportRequest":      {Required("user_id"), Currency("currency")},
+	"budget.GetNetWorthHistoryRequest": {
+		Required("user_id"), Date("start_date"), Date("end_date"), DateOrder("start_date", "end_date"),
+	},
+	"budget.PlanDebtPayoffRequest": {Required("user_id"), Positive("monthly_budget")},
+
+	// Reconciliation
+	"budget.StartReconciliationRequest": rulesOf(id("account_id"), []Rule{
+		Required("statement_date"), Date("statement_date"),
+	}),
+	"budget.MarkTransactionsClearedRequest": rulesOf(id("account_id"), []Rule{EachObjectID("transaction_ids")}),
+	"budget.FinishReconciliationRequest": rulesOf(id("account_id"), []Rule{
+		Required("statement_date"), Date("statement_date"),
+	}),
+	"budget.ListReconciliationsRequest": id("account_id"),
+
+	// Audit log
+	"budget.ListAuditEventsRequest": {NotNegative("limit")},
+
+	// User settings
+	"budget.GetUserSettingsRequest": {Required("user_id")},
+	"budget.UpdateUserSettingsRequest": {
+		Required("user_id"), TimeZone("time_zone"), Currency("base_currency"), OneOf("week_start", weekdays...),
+		Locale("locale"), NotNegative("version"),
+	},
+
+	// Households
+	"budget.CreateHouseholdRequest":  {Required("user_id"), Required("name")},
+	"budget.GetHouseholdByIdRequest": id("household_id"),
+	"budget.ListHouseholdsRequest":   {Required("user_id")},
+	"budget.UpdateHouseholdRequest":  rulesOf(id("household_id"), []Rule{Required("name"), Positive("version")}),
+	"budget.DeleteHouseholdRequest":  id("household_id"),
+	"budget.SetHouseholdMemberRequest": rulesOf(id("household_id"), []Rule{
+		Required("member_id"), Required("role"), OneOf("role", householdRoles...), Positive("version"),
+	}),
+	"budget.RemoveHouseholdMemberRequest": rulesOf(id("household_id"), []Rule{Required("member_id"), Positive("version")}),
+	"budget.ShareAccountRequest":          rulesOf(id("account_id"), []Rule{Required("user_id"), ObjectID("household_id")}),
+	"budget.ShareBudgetRequest":           rulesOf(id("budget_id"), []Rule{Required("user_id"), ObjectID("household_id")}),
+
+	// Expense splitting
+	"budget.CreateSplitExpenseRequest": rulesOf(id("account_id"), []Rule{
+		Required("user_id"), ObjectID("category_id"), Positive("amount"), Currency("currency"),
+		RequiredTime("date"), Timestamp("date"), TimeZone("time_zone"), ObjectID("household_id"),
+	}),
+	"budget.ListSplitExpensesRequest": {Required("user_id"), ObjectID("household_id")},
+	"budget.GetIousRequest":           {Required("user_id"), ObjectID("household_id")},
+	"budget.SimplifyDebtsRequest":     id("household_id"),
+	"budget.RecordSettlementRequest": rulesOf(id("from_account_id"), id("to_account_id"), id("household_id"), []Rule{
+		Required("user_id"), Required("creditor_id"), Different("user_id", "creditor_id"), Positive("amount"),
+		RequiredTime("date"), Timestamp("date"), TimeZone("time_zone"),
+	}),
+}
@@ -0,0 +1,321 @@
+// Package validation checks request messages against the rules declared for
+// them in rules.go before they reach the services.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"budget-service/dates"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	objectIDPattern = regexp.MustCompile(`^[0-9a-f]{24}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	localePattern   = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+)
+
+// Violation is one field that broke a rule
+type Violation struct {
+	Field       string
+	Description string
+}
+
+// Error holds every violation found in a request
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	var parts []string
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+": "+v.Description)
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+// Rule checks one aspect of a message and returns the violations it finds
+type Rule func(m protoreflect.Message) []Violation
+
+// Validate checks msg against its declared rules. Messages without rules are valid.
+func Validate(msg proto.Message) error {
+	m := msg.ProtoReflect()
+	var violations []Violation
+	for _, rule := range rules[m.Descriptor().FullName()] {
+		violations = append(violations, rule(m)...)
+	}
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// CheckRules runs every rule against an empty message, so a rule naming a message
+// or field that doesn't exist stops the server at startup instead of failing a request.
+// The generated messages must be registered, i.e. genproto imported, before it runs.
+func CheckRules() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	for name, list := range rules {
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(name)
+		if err != nil {
+			return fmt.Errorf("validation: unknown message %s", name)
+		}
+		m := mt.New()
+		for _, rule := range list {
+			rule(m)
+		}
+	}
+	return nil
+}
+
+// value returns the field's value, panicking on a misspelt rule so CheckRules catches it
+func value(m protoreflect.Message, field string) protoreflect.Value {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil {
+		panic(fmt.Sprintf("validation: %s has no field %s", m.Descriptor().FullName(), field))
+	}
+	return m.Get(fd)
+}
+
+func str(m protoreflect.Message, field string) string {
+	return value(m, field).String()
+}
+
+func number(m protoreflect.Message, field string) float64 {
+	v := value(m, field)
+	switch n := v.Interface().(type) {
+	case float32:
+		return float64(n)
+	case float64:
+		return n
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	panic(fmt.Sprintf("validation: %s.%s is not a number", m.Descriptor().FullName(), field))
+}
+
+// instant returns a google.protobuf.Timestamp field and whether it is set,
+// panicking when the field is of another type so CheckRules catches it
+func instant(m protoreflect.Message, field string) (*timestamppb.Timestamp, bool) {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil || fd.Message() == nil || fd.Message().FullName() != "google.protobuf.Timestamp" {
+		panic(fmt.Sprintf("validation: %s.%s is not a timestamp", m.Descriptor().FullName(), field))
+	}
+	if !m.Has(fd) {
+		return nil, false
+	}
+	ts := m.Get(fd).Message()
+	fields := ts.Descriptor().Fields()
+	return &timestamppb.Timestamp{
+		Seconds: ts.Get(fields.ByName("seconds")).Int(),
+		Nanos:   int32(ts.Get(fields.ByName("nanos")).Int()),
+	}, true
+}
+
+func violation(field, format string, args ...interface{}) []Violation {
+	return []Violation{{Field: field, Description: fmt.Sprintf(format, args...)}}
+}
+
+// Required rejects an empty string field
+func Required(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if strings.TrimSpace(str(m, field)) == "" {
+			return violation(field, "is required")
+		}
+		return nil
+	}
+}
+
+// ObjectID rejects a set field that isn't a hex object ID
+func ObjectID(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if v := str(m, field); v != "" && !objectIDPattern.MatchString(v) {
+			return violation(field, "must be a 24 character hex ID")
+		}
+		return nil
+	}
+}
+
+// Positive rejects a number that is zero or less
+func Positive(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if number(m, field) <= 0 {
+			return violation(field, "must be greater than 0")
+		}
+		return nil
+	}
+}
+
+// NotNegative rejects a number below zero
+func NotNegative(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if number(m, field) < 0 {
+			return violation(field, "must not be negative")
+		}
+		return nil
+	}
+}
+
+// Between rejects a set number outside [min, max]; zero counts as not set
+func Between(field string, min, max float64) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if n := number(m, field); n != 0 && (n < min || n > max) {
+			return violation(field, "must be between %v and %v", min, max)
+		}
+		return nil
+	}
+}
+
+// OneOf rejects a set string field that isn't one of values
+func OneOf(field string, values ...string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		v := str(m, field)
+		if v == "" {
+			return nil
+		}
+		for _, allowed := range values {
+			if v == allowed {
+				return nil
+			}
+		}
+		return violation(field, "must be one of %q", values)
+	}
+}
+
+// Date rejects a set field that isn't a YYYY-MM-DD date
+func Date(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if v := str(m, field); v != "" {
+			if _, err := time.Parse(dates.Layout, v); err != nil {
+				return violation(field, "must be a date in YYYY-MM-DD format")
+			}
+		}
+		return nil
+	}
+}
+
+// RequiredTime rejects an unset timestamp field
+func RequiredTime(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if _, ok := instant(m, field); !ok {
+			return violation(field, "is required")
+		}
+		return nil
+	}
+}
+
+// Timestamp rejects a set timestamp outside the range google.protobuf.Timestamp allows
+func Timestamp(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if ts, ok := instant(m, field); ok && ts.CheckValid() != nil {
+			return violation(field, "must be a valid timestamp")
+		}
+		return nil
+	}
+}
+
+// TimeOrder rejects an end timestamp before the start when both are set
+func TimeOrder(startField, endField string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		start, ok := instant(m, startField)
+		if !ok {
+			return nil
+		}
+		end, ok := instant(m, endField)
+		if !ok {
+			return nil
+		}
+		if end.AsTime().Before(start.AsTime()) {
+			return violation(endField, "must not be before %s", startField)
+		}
+		return nil
+	}
+}
+
+// TimeZone rejects a set field that isn't an IANA time zone name
+func TimeZone(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if v := str(m, field); v != "" {
+			if _, err := time.LoadLocation(v); err != nil {
+				return violation(field, "must be an IANA time zone such as Asia/Tashkent")
+			}
+		}
+		return nil
+	}
+}
+
+// Locale rejects a set field that isn't shaped like a BCP 47 language tag such as uz-UZ
+func Locale(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if v := str(m, field); v != "" && !localePattern.MatchString(v) {
+			return violation(field, "must be a language tag such as en or uz-UZ")
+		}
+		return nil
+	}
+}
+
+// Currency rejects a set field that isn't a three letter ISO 4217 code
+func Currency(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if v := str(m, field); v != "" && !currencyPattern.MatchString(v) {
+			return violation(field, "must be a three letter currency code such as USD")
+		}
+		return nil
+	}
+}
+
+// DateOrder rejects an end date before the start date when both are valid dates
+func DateOrder(startField, endField string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		start, err := time.Parse(dates.Layout, str(m, startField))
+		if err != nil {
+			return nil
+		}
+		end, err := time.Parse(dates.Layout, str(m, endField))
+		if err != nil {
+			return nil
+		}
+		if end.Before(start) {
+			return violation(endField, "must not be before %s", startField)
+		}
+		return nil
+	}
+}
+
+// Different rejects two set fields holding the same value
+func Different(field, otherField string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		if v := str(m, field); v != "" && v == str(m, otherField) {
+			return violation(otherField, "must differ from %s", field)
+		}
+		return nil
+	}
+}
+
+// EachObjectID rejects elements of a repeated field that aren't hex object IDs
+func EachObjectID(field string) Rule {
+	return func(m protoreflect.Message) []Violation {
+		list := value(m, field).List()
+		var violations []Violation
+		for i := 0; i < list.Len(); i++ {
+			if !objectIDPattern.MatchString(list.Get(i).String()) {
+				violations = append(violations, violation(fmt.Sprintf("%s[%d]", field, i), "must be a 24 character hex ID")...)
+			}
+		}
+		return violations
+	}
+}